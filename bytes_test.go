@@ -0,0 +1,83 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/rwxrob/get"
+)
+
+// testdata/binary contains NUL bytes and invalid UTF-8
+
+func ExampleBytes() {
+
+	byt, err := get.Bytes(`file:testdata/binary`)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Printf("%q\n", byt)
+
+	byt, err = get.Bytes(`file.tail:testdata/binary`)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Printf("%q\n", byt)
+
+	// Output:
+	// "bin\x00ary \xff\xfe\n\x00\xc3( last\n"
+	// "\x00\xc3( last"
+}
+
+func ExampleBytes_http() {
+
+	bin, _ := os.ReadFile(`testdata/binary`)
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(`Content-Type`, `application/octet-stream`)
+			w.Write(bin)
+		})
+	svr := httptest.NewServer(handler)
+	defer svr.Close()
+
+	byt, err := get.Bytes(svr.URL)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Printf("%q\n", byt)
+
+	// Output:
+	// "bin\x00ary \xff\xfe\n\x00\xc3( last\n"
+}
+
+func ExampleBytes_scp() {
+
+	g := &get.Getter{SCPPath: `testdata/fakescp`}
+
+	byt, err := g.Bytes(`scp://localhost/testdata/binary`)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Printf("%q\n", byt)
+
+	// Output:
+	// "bin\x00ary \xff\xfe\n\x00\xc3( last\n"
+}
+
+func ExampleBytes_ssh() {
+
+	g := &get.Getter{SSHPath: `testdata/fakessh`}
+
+	byt, err := g.Bytes(`ssh://localhost/testdata/binary`)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Printf("%q\n", byt)
+
+	// Output:
+	// "bin\x00ary \xff\xfe\n\x00\xc3( last\n"
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// decodeBase64 decodes standard (b64) or URL-safe (b64url) base64 data
+// with or without padding. All white space is removed first.
+func decodeBase64(padded, raw *base64.Encoding) func([]byte) ([]byte, error) {
+	return func(in []byte) ([]byte, error) {
+		in = removeSpace(in)
+		enc := padded
+		if !bytes.HasSuffix(in, []byte(`=`)) && len(in)%4 != 0 {
+			enc = raw
+		}
+		out := make([]byte, enc.DecodedLen(len(in)))
+		n, err := enc.Decode(out, in)
+		return out[:n], err
+	}
+}
+
+// decodeHex decodes hexadecimal data after removing all white space.
+func decodeHex(in []byte) ([]byte, error) {
+	in = removeSpace(in)
+	out := make([]byte, hex.DecodedLen(len(in)))
+	n, err := hex.Decode(out, in)
+	return out[:n], err
+}
+
+// gunzipModifier returns the gunzip modifier, which takes no arguments.
+func gunzipModifier(args []string) (modifier, bool) {
+	if len(args) > 0 {
+		return modifier{}, false
+	}
+	return modifier{
+		name: `gunzip`,
+		apply: func(_ context.Context, g *Getter, in []byte, _ string) ([]byte, error) {
+			out, err := g.gunzip(in)
+			return out, fetchErr(`gunzip`, ``, err)
+		},
+	}, true
+}
+
+// gunzip decompresses gzip data returning a TooLargeError if the
+// result would be larger than the MaxSize of the Getter.
+func (g *Getter) gunzip(in []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(in))
+	if err != nil {
+		return nil, err
+	}
+	r, err := g.limit(zr, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// trim removes leading and trailing white space.
+func trim(in []byte) ([]byte, error) {
+	return bytes.TrimSpace(in), nil
+}
+
+func removeSpace(in []byte) []byte {
+	return []byte(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, string(in)))
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"os"
+	"testing/fstest"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleString_b64() {
+
+	os.Setenv(`KUBE_TOKEN`, "c29tZXRva2VuCg==\n") // sometoken\n
+	defer os.Unsetenv(`KUBE_TOKEN`)
+	os.Setenv(`URL_TOKEN`, `c29tZT90b2tlbj8_`) // some?token?? (unpadded)
+	defer os.Unsetenv(`URL_TOKEN`)
+	os.Setenv(`HEX_TOKEN`, "736f6d65\n746f6b656e")
+	defer os.Unsetenv(`HEX_TOKEN`)
+
+	for _, target := range []string{
+		`env.b64:KUBE_TOKEN`,
+		`env.b64.trim:KUBE_TOKEN`,
+		`env.b64url:URL_TOKEN`,
+		`env.hex:HEX_TOKEN`,
+	} {
+		it, err := get.String(target)
+		fmt.Printf("%q %v\n", it, err)
+	}
+
+	// Output:
+	// "sometoken\n" <nil>
+	// "sometoken" <nil>
+	// "some?token??" <nil>
+	// "sometoken" <nil>
+}
+
+func ExampleString_gunzip() {
+
+	buf := new(bytes.Buffer)
+	zw := gzip.NewWriter(buf)
+	zw.Write([]byte(`{"id": "abc123"}`))
+	zw.Close()
+
+	// much larger once decompressed
+	bomb := new(bytes.Buffer)
+	zw = gzip.NewWriter(bomb)
+	zw.Write(make([]byte, 100000))
+	zw.Close()
+
+	g := &get.Getter{
+		MaxSize: 1000,
+		FS: fstest.MapFS{
+			`data.gz`: {Data: buf.Bytes()},
+			`bomb.gz`: {Data: bomb.Bytes()},
+		},
+	}
+
+	fmt.Println(g.String(`file.gunzip.json:data.gz#id`))
+
+	_, err := g.String(`file.gunzip:bomb.gz`)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, get.ErrTooLarge))
+
+	// Output:
+	// abc123 <nil>
+	// get: file.gunzip: gunzip file.gunzip:bomb.gz: too large: exceeds limit of 1000 bytes
+	// true
+}
+
+func ExampleString_b64_malformed() {
+
+	os.Setenv(`BAD_TOKEN`, `not*base64`)
+	defer os.Unsetenv(`BAD_TOKEN`)
+
+	_, err := get.String(`env.b64:BAD_TOKEN`)
+	fmt.Println(err)
+
+	_, err = get.String(`env.gunzip:BAD_TOKEN`)
+	fmt.Println(err)
+
+	// Output:
+	// get: env.b64: b64 env.b64:BAD_TOKEN: illegal base64 data at input byte 3
+	// get: env.gunzip: gunzip env.gunzip:BAD_TOKEN: gzip: invalid header
+}
@@ -21,10 +21,12 @@ For more details see the [String] function.
 
 # Binary data ([]byte)
 
-This package is primarily for use with textual files. However, some
-return values may work by simply casting to []byte slice. The exported
-helper functions (used by [String]) that access local or remote files
-also return []byte slices and may be used directly.
+This package is primarily for use with textual files. However, the
+[Bytes] function supports every schema (including the line selection
+modifiers) returning the data exactly as received, and [Open] streams
+it without buffering. The exported helper functions (used by [String])
+that access local or remote files also return []byte slices and may be
+used directly.
 
 # Consider "vendoring"
 
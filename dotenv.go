@@ -0,0 +1,218 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// dotenvFetcher reads the local dotenv file before the # and returns
+// the value of the key after it (ex: ./.env#DATABASE_URL).
+type dotenvFetcher struct{}
+
+func (dotenvFetcher) Open(ctx context.Context, g *Getter, value string) (io.ReadCloser, error) {
+	name, key, _ := strings.Cut(value, `#`)
+	byt, err := g.readFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	val, err := g.selectDotenv(byt, key)
+	if err != nil {
+		return nil, fetchErr(`dotenv`, key, err)
+	}
+	return io.NopCloser(strings.NewReader(val)), nil
+}
+
+// dotenvModifier takes no arguments and returns the value of the
+// #selector key (see selectDotenv).
+func dotenvModifier(args []string) (modifier, bool) {
+	if len(args) > 0 {
+		return modifier{}, false
+	}
+	return modifier{
+		name:     `dotenv`,
+		selector: true,
+		apply: func(_ context.Context, g *Getter, in []byte, key string) ([]byte, error) {
+			val, err := g.selectDotenv(in, key)
+			return []byte(val), fetchErr(`dotenv`, key, err)
+		},
+	}, true
+}
+
+// selectDotenv returns the value of the key from the dotenv data. Keys
+// are never added to the environment. Returns ErrNotFound if the key
+// is not set in the data.
+func (g *Getter) selectDotenv(in []byte, key string) (string, error) {
+	if len(key) == 0 {
+		return ``, fmt.Errorf(`%w: missing #KEY`, ErrInvalidURI)
+	}
+	vars, err := parseDotenv(string(in), g.lookupEnv)
+	if err != nil {
+		return ``, err
+	}
+	val, has := vars[key]
+	if !has {
+		return ``, ErrNotFound
+	}
+	return val, nil
+}
+
+// parseDotenv returns the variables from the KEY=value lines of a
+// dotenv file using the same rules as most shells and dotenv libraries:
+//
+//   - blank lines and lines beginning with # are ignored
+//   - an optional export prefix is ignored
+//   - unquoted values are trimmed and end at any # after a space
+//   - single-quoted values are taken literally
+//   - double-quoted values may contain \n, \t, \", \\, and \$ escapes
+//   - quoted values may span multiple lines
+//   - $VAR, ${VAR}, and ${VAR:-default} are expanded (except within
+//     single quotes) from the variables already defined in the data
+//     and then from lookup
+func parseDotenv(text string, lookup func(string) (string, bool)) (map[string]string, error) {
+	vars := map[string]string{}
+	get := func(name string) (string, bool) {
+		if v, has := vars[name]; has {
+			return v, true
+		}
+		return lookup(name)
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	line := 1
+	for len(text) > 0 {
+		var stmt string
+		stmt, text, _ = strings.Cut(text, "\n")
+		start := line
+		line++
+
+		stmt = strings.TrimSpace(stmt)
+		if len(stmt) == 0 || stmt[0] == '#' {
+			continue
+		}
+		if rest := strings.TrimPrefix(stmt, `export`); len(rest) < len(stmt) &&
+			len(rest) > 0 && (rest[0] == ' ' || rest[0] == '\t') {
+			stmt = strings.TrimSpace(rest)
+		}
+		key, val, found := strings.Cut(stmt, `=`)
+		key = strings.TrimSpace(key)
+		if !found || len(key) == 0 || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf(`dotenv: line %v: expected KEY=value`, start)
+		}
+		val = strings.TrimLeft(val, " \t")
+
+		// quoted values continue until the closing quote
+		if len(val) > 0 && (val[0] == '\'' || val[0] == '"') {
+			for dotenvQuoteEnd(val) < 0 && len(text) > 0 {
+				var next string
+				next, text, _ = strings.Cut(text, "\n")
+				val += "\n" + next
+				line++
+			}
+		}
+
+		var err error
+		switch {
+		case strings.HasPrefix(val, `'`):
+			end := dotenvQuoteEnd(val)
+			if end < 0 {
+				return nil, fmt.Errorf(`dotenv: line %v: unterminated quote`, start)
+			}
+			val = val[1:end]
+		case strings.HasPrefix(val, `"`):
+			end := dotenvQuoteEnd(val)
+			if end < 0 {
+				return nil, fmt.Errorf(`dotenv: line %v: unterminated quote`, start)
+			}
+			val, err = expandDotenv(val[1:end], true, get)
+		default:
+			if i := strings.Index(val, ` #`); i >= 0 {
+				val = val[:i]
+			}
+			if i := strings.Index(val, "\t#"); i >= 0 {
+				val = val[:i]
+			}
+			val, err = expandDotenv(strings.TrimSpace(val), false, get)
+		}
+		if err != nil {
+			return nil, fmt.Errorf(`dotenv: line %v: %w`, start, err)
+		}
+		vars[key] = val
+	}
+	return vars, nil
+}
+
+// dotenvQuoteEnd returns the index of the quote that closes the one at
+// the start of val or -1 if there is none.
+func dotenvQuoteEnd(val string) int {
+	q := val[0]
+	for i := 1; i < len(val); i++ {
+		switch {
+		case val[i] == '\\' && q == '"':
+			i++
+		case val[i] == q:
+			return i
+		}
+	}
+	return -1
+}
+
+// expandDotenv expands variables and (if escapes) backslash escapes.
+func expandDotenv(s string, escapes bool, get func(string) (string, bool)) (string, error) {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+
+		case c == '\\' && escapes && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				buf.WriteByte('\n')
+			case 'r':
+				buf.WriteByte('\r')
+			case 't':
+				buf.WriteByte('\t')
+			case '"', '\\', '$':
+				buf.WriteByte(s[i])
+			default:
+				buf.WriteByte('\\')
+				buf.WriteByte(s[i])
+			}
+
+		case c == '$' && i+1 < len(s) && s[i+1] == '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return ``, fmt.Errorf(`missing } in %v`, s[i:])
+			}
+			name, def, hasDef := strings.Cut(s[i+2:i+end], `:-`)
+			if v, _ := get(name); len(v) > 0 || !hasDef {
+				buf.WriteString(v)
+			} else {
+				buf.WriteString(def)
+			}
+			i += end
+
+		case c == '$' && i+1 < len(s) && isDotenvName(s[i+1]):
+			end := i + 1
+			for end < len(s) && isDotenvName(s[end]) {
+				end++
+			}
+			v, _ := get(s[i+1 : end])
+			buf.WriteString(v)
+			i = end - 1
+
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String(), nil
+}
+
+func isDotenvName(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' || c == '_'
+}
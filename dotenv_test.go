@@ -0,0 +1,71 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"errors"
+	"fmt"
+	"testing/fstest"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleString_dotenv() {
+
+	g := &get.Getter{
+		LookupEnv: func(key string) (string, bool) {
+			if key == `HOST` {
+				return `db.example.com`, true
+			}
+			return ``, false
+		},
+		FS: fstest.MapFS{
+			`app/.env`: {Data: []byte(`# local settings
+export DB_USER=admin
+DB_PASS='pa$$word'   # literal
+DATABASE_URL="postgres://${DB_USER}@${HOST}/app"
+GREETING="hello\nworld"
+PORT=5432 # default port
+KEY="-----BEGIN KEY-----
+abc
+-----END KEY-----"
+`)},
+		},
+	}
+
+	for _, target := range []string{
+		`dotenv:app/.env#DATABASE_URL`,
+		`dotenv:app/.env#DB_PASS`,
+		`dotenv:app/.env#GREETING`,
+		`dotenv:app/.env#PORT`,
+		`dotenv:app/.env#KEY`,
+		`file.dotenv:app/.env#DB_USER`,
+	} {
+		it, err := g.String(target)
+		fmt.Printf("%q %v\n", it, err)
+	}
+
+	// Output:
+	// "postgres://admin@db.example.com/app" <nil>
+	// "pa$$word" <nil>
+	// "hello\nworld" <nil>
+	// "5432" <nil>
+	// "-----BEGIN KEY-----\nabc\n-----END KEY-----" <nil>
+	// "admin" <nil>
+}
+
+func ExampleString_dotenv_missing() {
+
+	g := &get.Getter{
+		FS: fstest.MapFS{`.env`: {Data: []byte("USER=me\n")}},
+	}
+
+	_, err := g.String(`dotenv:.env#PASSWORD`)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, get.ErrNotFound))
+
+	// Output:
+	// get: dotenv: dotenv PASSWORD: not found
+	// true
+}
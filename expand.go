@@ -0,0 +1,107 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// Expand returns the text with every {{get "target"}} replaced with the
+// data from the target (see String). The text is a text/template so
+// that any of its actions and functions may also be used (ex: {{get
+// "conf:app/token" | printf "%q"}}). The first error from any target
+// is returned as is rather than within a template error.
+func Expand(text string) (string, error) {
+	return Default.Expand(text)
+}
+
+// ExpandContext is the same as Expand but with a context (see
+// StringContext).
+func ExpandContext(ctx context.Context, text string) (string, error) {
+	return Default.ExpandContext(ctx, text)
+}
+
+// Expand is the same as the package Expand function but uses the
+// configuration of the Getter.
+func (g *Getter) Expand(text string) (string, error) {
+	return g.ExpandContext(context.Background(), text)
+}
+
+// ExpandContext is the same as the package ExpandContext function but
+// uses the configuration of the Getter.
+func (g *Getter) ExpandContext(ctx context.Context, text string) (string, error) {
+	var getErr error
+	funcs := template.FuncMap{
+		`get`: func(target string) (string, error) {
+			val, err := g.StringContext(ctx, target)
+			if err != nil && getErr == nil {
+				getErr = err
+			}
+			return val, err
+		},
+	}
+	tmpl, err := template.New(`expand`).Funcs(funcs).Parse(text)
+	if err != nil {
+		return ``, fetchErr(`expand`, ``, err)
+	}
+	buf := new(strings.Builder)
+	if err := tmpl.Execute(buf, nil); err != nil {
+		if getErr != nil {
+			return ``, getErr
+		}
+		return ``, fetchErr(`expand`, ``, err)
+	}
+	return buf.String(), nil
+}
+
+// expandValue replaces every ${VAR} in the value with the value of the
+// environment variable (see LookupEnv) and a leading ~ with the home
+// directory (see HomeDir). The ~ is left alone for the home, conf, and
+// cache sources since their paths are already relative to a directory.
+// An unset variable returns ErrNotFound.
+func (g *Getter) expandValue(source, value string) (string, error) {
+	if !inDirSource(source) &&
+		(value == `~` || strings.HasPrefix(value, `~/`)) {
+		home, err := g.homeDir()
+		if err != nil {
+			return ``, err
+		}
+		value = path.Join(home, value[1:])
+	}
+	var buf strings.Builder
+	for {
+		i := strings.Index(value, `${`)
+		if i < 0 {
+			buf.WriteString(value)
+			return buf.String(), nil
+		}
+		end := strings.IndexByte(value[i:], '}')
+		if end < 0 {
+			return ``, &FetchError{Op: `expand`, Target: value[i:],
+				Err: fmt.Errorf(`%w: missing }`, ErrInvalidURI)}
+		}
+		name := value[i+2 : i+end]
+		val, set := g.lookupEnv(name)
+		if !set {
+			return ``, &FetchError{Op: `expand`, Target: name, Err: ErrNotFound}
+		}
+		buf.WriteString(value[:i])
+		buf.WriteString(val)
+		value = value[i+end+1:]
+	}
+}
+
+// inDirSource returns true if the paths of the source are relative to
+// one of the base directories of the Getter.
+func inDirSource(source string) bool {
+	switch source {
+	case `home`, `conf`, `cache`:
+		return true
+	}
+	return false
+}
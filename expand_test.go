@@ -0,0 +1,85 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing/fstest"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleExpand() {
+
+	g := &get.Getter{
+		ConfDir: `/conf`,
+		LookupEnv: func(key string) (string, bool) {
+			return map[string]string{`USER`: `me`}[key], key == `USER`
+		},
+		FS: fstest.MapFS{
+			`conf/app/token`: {Data: []byte("sometoken\n")},
+		},
+	}
+
+	fmt.Println(g.Expand(`{{get "env:USER"}}:{{get "conf.head:app/token"}}`))
+
+	_, err := g.Expand(`token={{get "conf:app/missing"}}`)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, get.ErrNotFound))
+
+	// Output:
+	// me:sometoken <nil>
+	// get: conf: read /conf/app/missing: file does not exist
+	// true
+}
+
+func ExampleGetter_expandValues() {
+
+	svr := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, r.URL.Path)
+		}))
+	defer svr.Close()
+
+	env := map[string]string{
+		`XDG_RUNTIME_DIR`: `/run/user/1000`,
+		`ENV`:             `prod`,
+		`SVR`:             svr.URL,
+	}
+
+	g := &get.Getter{
+		ExpandValues: true,
+		HomeDir:      `/home/me`,
+		LookupEnv: func(key string) (string, bool) {
+			val, has := env[key]
+			return val, has
+		},
+		FS: fstest.MapFS{
+			`run/user/1000/app/token`: {Data: []byte(`runtime-token`)},
+			`home/me/.token`:          {Data: []byte(`home-token`)},
+		},
+	}
+
+	fmt.Println(g.String(`file:${XDG_RUNTIME_DIR}/app/token`))
+	fmt.Println(g.String(`file:~/.token`))
+	fmt.Println(g.String(`home:.token`))
+	fmt.Println(g.String(`home:${ENV}/../.token`))
+	fmt.Println(g.String(`http:${SVR}/${ENV}/token`))
+	fmt.Println(g.String(`plain ${ENV}`))
+
+	_, err := g.String(`file:${UNSET}/token`)
+	fmt.Println(err)
+
+	// Output:
+	// runtime-token <nil>
+	// home-token <nil>
+	// home-token <nil>
+	// home-token <nil>
+	// /prod/token <nil>
+	// plain ${ENV} <nil>
+	// get: file: expand UNSET: not found
+}
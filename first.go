@@ -0,0 +1,164 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// First returns the data from the first target (see String) that
+// succeeds with a non-empty result trying each in order. A target that
+// is not found (ErrNotFound, including an HTTP 404) or empty
+// (ErrEmptyValue) is skipped. Any other error (invalid target,
+// permission denied, TLS or network failure, etc.) is returned
+// immediately so that a misconfigured source does not silently fall
+// through to the next. If every target is skipped a FallbackError
+// listing every attempt is returned. Since a plain string is returned
+// as is, the last target may be a default. The same may be done within
+// a single target string by separating the targets with || (ex:
+// env:TOKEN||conf.head:app/token), but every target must then start
+// with a registered source.
+func First(targets ...string) (string, error) {
+	return Default.First(targets...)
+}
+
+// FirstContext is the same as First but with a context (see
+// StringContext).
+func FirstContext(ctx context.Context, targets ...string) (string, error) {
+	return Default.FirstContext(ctx, targets...)
+}
+
+// First is the same as the package First function but uses the
+// configuration of the Getter.
+func (g *Getter) First(targets ...string) (string, error) {
+	return g.FirstContext(context.Background(), targets...)
+}
+
+// FirstContext is the same as the package FirstContext function but
+// uses the configuration of the Getter.
+func (g *Getter) FirstContext(ctx context.Context, targets ...string) (string, error) {
+	byt, err := g.first(ctx, targets)
+	return string(byt), err
+}
+
+// first returns the data from the first target that succeeds with
+// a non-empty result skipping only those not found or empty. Stops
+// early if the context is done.
+func (g *Getter) first(ctx context.Context, targets []string) ([]byte, error) {
+	fe := new(FallbackError)
+	for _, target := range targets {
+		byt, err := g.BytesContext(ctx, target)
+		if err == nil && len(byt) == 0 {
+			schema, _ := Schema(target)
+			err = &FetchError{schema, Sanitize(target), `fetch`, ErrEmptyValue}
+		}
+		if err == nil {
+			return byt, nil
+		}
+		if !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrEmptyValue) {
+			return nil, err
+		}
+		fe.Attempts = append(fe.Attempts, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, fe
+}
+
+// splitChain returns the targets separated by || if the first has
+// a registered source. Only a || followed by a registered source
+// separates targets so that any other || remains a part of the value
+// or selector (ex: file.re:x.txt#(foo||bar)). Returns nil if there is
+// nothing to split (including plain strings that happen to contain
+// ||).
+func splitChain(target string) []string {
+	if !strings.Contains(target, `||`) {
+		return nil
+	}
+	parts := strings.Split(target, `||`)
+	if !hasSource(parts[0]) {
+		return nil
+	}
+	chain := parts[:1]
+	for _, part := range parts[1:] {
+		if hasSource(part) {
+			chain = append(chain, part)
+			continue
+		}
+		chain[len(chain)-1] += `||` + part
+	}
+	if len(chain) == 1 {
+		return nil
+	}
+	return chain
+}
+
+// hasSource returns true if the target starts with a registered source
+// (even if its modifiers are invalid).
+func hasSource(target string) bool {
+	_, f, _, err := parseTarget(target)
+	return f != nil || err != nil
+}
+
+// openChain returns a reader of the data from the first of the targets
+// that succeeds.
+func (g *Getter) openChain(ctx context.Context, chain []string) (io.ReadCloser, error) {
+	byt, err := g.first(ctx, chain)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(byt)), nil
+}
+
+// FallbackError is returned from First (or a target with ||) when every
+// target fails. Attempts contains the error from each target in order
+// (ErrEmptyValue for any that returned nothing). Both errors.Is and
+// errors.As check every attempt.
+type FallbackError struct {
+	Attempts []error
+}
+
+// Error fulfills the error interface listing every attempt.
+func (e *FallbackError) Error() string {
+	if len(e.Attempts) == 0 {
+		return `get: no targets`
+	}
+	msgs := make([]string, len(e.Attempts))
+	for i, err := range e.Attempts {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf(`get: all %v targets failed: %v`,
+		len(e.Attempts), strings.Join(msgs, `; `))
+}
+
+// Unwrap returns every attempt.
+func (e *FallbackError) Unwrap() []error { return e.Attempts }
+
+// Is returns true if any of the attempts match the target (see
+// errors.Is).
+func (e *FallbackError) Is(target error) bool {
+	for _, err := range e.Attempts {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the attempts that matches the target (see
+// errors.As). Unwrap alone is not enough before Go 1.20.
+func (e *FallbackError) As(target any) bool {
+	for _, err := range e.Attempts {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing/fstest"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleFirst() {
+
+	svr := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `team-token`)
+		}))
+	defer svr.Close()
+
+	fmt.Println(get.First(
+		`env:SOME_UNSET_TOKEN`,
+		`file:testdata/nothing-here`,
+		svr.URL,
+	))
+
+	// Output:
+	// team-token <nil>
+}
+
+func ExampleFirst_chain() {
+
+	g := &get.Getter{
+		ConfDir:   `/conf`,
+		LookupEnv: func(string) (string, bool) { return ``, true },
+		FS: fstest.MapFS{
+			`conf/app/token`: {Data: []byte("conf-token\nsecond line\n")},
+			`conf/app/notes`: {Data: []byte("bar=42\n")},
+		},
+	}
+
+	fmt.Println(g.String(`env:TOKEN||conf.head:app/token`))
+	fmt.Println(g.String(`env:TOKEN||conf.head:app/missing||conf.head:app/token`))
+	fmt.Println(g.String(`not||a||chain`))
+
+	// only split before a registered source
+	fmt.Println(g.String(`conf.re:app/notes#(?:foo||bar)=(\d+)`))
+	fmt.Println(g.String(`env:TOKEN||conf.re:app/notes#(?:foo||bar)=(\d+)`))
+
+	// plain defaults only with First
+	fmt.Println(g.First(`env:TOKEN`, `conf:app/missing`, `default-token`))
+
+	// Output:
+	// conf-token <nil>
+	// conf-token <nil>
+	// not||a||chain <nil>
+	// 42 <nil>
+	// 42 <nil>
+	// default-token <nil>
+}
+
+func ExampleFallbackError() {
+
+	g := &get.Getter{
+		LookupEnv: func(key string) (string, bool) { return ``, key == `EMPTY` },
+		FS:        fstest.MapFS{},
+	}
+
+	_, err := g.First(`env:TOKEN`, `env:EMPTY`, `file:token`)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, get.ErrNotFound), errors.Is(err, get.ErrEmptyValue))
+
+	var fe *get.FallbackError
+	if errors.As(err, &fe) {
+		fmt.Println(len(fe.Attempts))
+
+		// also without support for Unwrap() []error (before Go 1.20)
+		var first *get.FetchError
+		fmt.Println(fe.As(&first), first.Schema)
+	}
+
+	// Output:
+	// get: all 3 targets failed: get: env: env TOKEN: not found; get: env: env EMPTY: empty value; get: file: read token: file does not exist
+	// true true
+	// 3
+	// true env
+}
+
+func ExampleFirst_errors() {
+
+	svr := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `team-token`)
+		}))
+	defer svr.Close()
+
+	g := &get.Getter{
+		MaxSize: 5,
+		FS: fstest.MapFS{
+			`big`: {Data: []byte(`much too large`)},
+		},
+	}
+
+	// not found and empty are skipped, anything else is returned
+	_, err := g.First(`file:missing`, `file:big`, svr.URL)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, get.ErrTooLarge))
+
+	_, err = g.First(`file.haed:missing`, svr.URL)
+	fmt.Println(errors.Is(err, get.ErrInvalidURI))
+
+	// Output:
+	// get: file: read big: too large: 14 bytes exceeds limit of 5
+	// true
+	// true
+}
@@ -5,69 +5,29 @@ package get
 
 import (
 	"bufio"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
-	"os/exec"
 	"path"
 	"strings"
 
 	"github.com/rwxrob/get/expr"
 )
 
-// Schema returns the schema up to the first colon if found. Only valid
-// schema combinations will be returned (see source switch for all
-// possible schemas). All others return an empty string for the schema.
-// The second string is always the remaining value (after the colon).
-// See the String function for summary of schemas.
+// Schema returns the schema up to the first colon if found. Only
+// registered schemas (see Register and Schemes) combined with valid
+// modifiers will be returned. All others return an empty string for the
+// schema. The second string is always the remaining value (after the
+// colon). See the String function for summary of built-in schemas and
+// ParseTarget for the full grammar.
 func Schema(a string) (schema, value string) {
-	parts := strings.SplitN(a, `:`, 2)
-	count := len(parts)
-
-	switch count {
-	case 2:
-		schema = parts[0]
-		value = parts[1]
-	case 1:
-		value = parts[0]
-		return
-	default:
-		value = a
-		return
-	}
-
-	switch schema {
-	case `env`, `env.head`, `env.tail`:
-		return
-	case `env.file`, `env.file.head`, `env.file.tail`:
-		return
-	case `file`, `file.head`, `file.tail`:
-		return
-	case `head`, `tail`:
-		return
-	case `home`, `home.head`, `home.tail`:
-		return
-	case `conf`, `conf.head`, `conf.tail`:
-		return
-	case `cache`, `cache.head`, `cache.tail`:
-		return
-	case `scp`:
-		return
-	case `ssh.head`, `ssh.tail`:
-		return
-	case `http`, `http.head`, `http.tail`:
-		return
-	case `https`, `https.head`, `https.tail`:
-		return
+	t, _, _, err := parseTarget(a)
+	if err != nil || len(t.Source) == 0 {
+		// looks like we just have a plain string
+		return ``, a
 	}
-
-	// looks like we just have a plain string
-	schema = ``
-	value = a
-
+	schema, value, _ = strings.Cut(a, `:`)
 	return
-
 }
 
 // String returns it's string argument unless one of the following special URL
@@ -77,49 +37,202 @@ func Schema(a string) (schema, value string) {
 // persist string values, for example secrets in files or on secured,
 // remote https or ssh locations :
 //
-//	(none)         - string as is
-//	env            - value of environment variable by name
-//	env.head       - file line of value of environment variable by name
-//	env.tail       - tail line of value of environment variable by name
-//	env.file       - full content file at path from environment variable
-//	env.file.head  - head line of file at path from environment variable
-//	env.file.tail  - tail line of file at path from environment variable
-//	file           - full content of local file at path
-//	file.head      - head line of file
-//	file.tail      - tail line of file
-//	head           - (same as file.head)
-//	tail           - (same as file.tail)
-//	home           - full content of local file relative to os.UserHomeDir
-//	home.head      - head line of home
-//	home.tail      - tail line of home
-//	conf           - full content of local file relative os.UserConfigDir
-//	conf.head      - head line of conf
-//	conf.tail      - tail line of conf
-//	cache          - full content of local file relative os.UserCacheDir
-//	cache.head     - head line of cache
-//	cache.tail     - tail line of cache
-//	scp            - full content of remote file over scp
-//	ssh.head       - head line of remote file with ssh head -1
-//	ssh.tail       - tail line of remote file with ssh tail -1
-//	http(s)        - full content of remote HTTP/TLS GET (net/http.DefaultClient)
-//	http(s).head   - head line of http(s) (from full GET)
-//	http(s).tail   - tail line of https(s) (from full GET)
+//	(none)    - string as is
+//	env       - value of environment variable by name
+//	env.file  - full content file at path from environment variable
+//	file      - full content of local file at path
+//	home      - full content of local file relative to os.UserHomeDir
+//	conf      - full content of local file relative os.UserConfigDir
+//	cache     - full content of local file relative os.UserCacheDir
+//	embed     - full content of file from the Getter Embed file system
+//	dotenv    - value of #KEY from local dotenv file (./.env#DB_URL)
+//	netrc     - password (or #login, #account) for host from ~/.netrc
+//	scp       - full content of remote file over scp
+//	ssh       - full content of remote file with ssh cat
+//	http(s)   - full content of remote HTTP/TLS GET (error unless 2xx)
+//	head      - (same as file.head)
+//	tail      - (same as file.tail)
+//
+// Any of these sources may be followed by any of the modifiers below
+// in any combination (ex: home.head, ssh.tail.3, conf.json, env.b64.trim).
+// See [Target] for the complete grammar. The head and tail lines of
+// ssh are selected on the remote host and the http(s) head stops
+// reading the body after the first line.
 //
 // For more information about how the data is acquired and parsed see
 // the relevant helper functions ([HomeFile], [CacheFile], [ConfFile]
 //
+// # Line selection
+//
+// The following modifiers may be added to any schema to select
+// specific lines (see [Lines]):
+//
+//	head       - first line (ex: home.head)
+//	tail       - last line (ex: cache.tail)
+//	head.N     - first N lines (ex: file.head.3)
+//	tail.N     - last N lines (ex: ssh.tail.10)
+//	line.N     - line N only (ex: conf.line.2)
+//	lines.A-B  - lines A through B (ex: https.lines.2-5)
+//
+// Multiple lines are joined with a line feed and no line ending is
+// included after the last. Local files and HTTP bodies are streamed
+// and only read as far as needed. For ssh the lines are selected on the
+// remote host (with head, tail, or sed) so that only they are
+// transferred. For http(s) lines counted from the end (tail) are
+// fetched with growing Range requests when the server supports them
+// (see [LastLineOfHTTP]).
+//
+// # Data selection
+//
+// The following modifiers parse the fetched data and return only the
+// value at the selector that follows the first # in the target. They
+// may be added to any schema (ex: conf.json:gcloud/creds.json#client_secret)
+// and combined with any of the other modifiers. Selecting a missing
+// value returns ErrNotFound.
+//
+//	json    - value at dotted path with [N] indexes (ex: #data.items[0].token)
+//	yaml    - scalar at dotted key path (ex: #github.com.oauth_token)
+//	toml    - scalar at dotted key path (ex: #servers.alpha.ip)
+//	dotenv  - value of KEY=value line (ex: #DATABASE_URL)
+//	ini     - value of key in section (ex: #default.aws_access_key_id)
+//	re      - first capture group of regular expression (ex: #TOKEN=(\S+))
+//	re.NAME - named capture group (ex: re.tok with #TOKEN=(?P<tok>\S+))
+//
+// For json, strings are returned without quotes and objects and arrays
+// as compact JSON. Keys containing dots may be quoted (ex:
+// #auths["ghcr.io"].auth). The http(s) schemas also accept a full URL
+// as the value (ex: https.json:https://host/meta#data.token).
+//
+// For yaml and toml, the longest key matching the dotted parts is used
+// at each level so that keys may contain dots and list items are
+// selected by number (ex: #hosts.0.name). Selecting a map or list
+// rather than a single value returns ErrNotScalar. Only the YAML
+// commonly used for configuration is supported (no anchors, aliases,
+// or multiple documents).
+//
+// For dotenv, the data is parsed like a shell would (quotes, export
+// prefixes, comments, and $VAR or ${VAR} expansion from earlier keys
+// and then the environment) but nothing is ever added to the
+// environment.
+//
+// For ini, the selector is split at the last dot into the section and
+// key (ex: home.ini:.gitconfig#remote "origin".url) and a selector
+// without a dot selects a key before the first section. Comments (; or
+// #), quoted values, and continuation lines (indented or after
+// a backslash) are all handled.
+//
+// For re, the regular expression (see regexp/syntax) is everything
+// after the first # and is matched against all of the data (use (?m)
+// for ^ and $ to match at line boundaries). The entire match is
+// returned when there are no groups and ErrNotFound when nothing
+// matches (ex: file.re:/etc/app.sh#export TOKEN=(\S+)).
+//
+// # Decoding
+//
+// The following modifiers transform all of the data and may be added
+// to any schema:
+//
+//	b64     - decode standard base64 (padded or not)
+//	b64url  - decode URL-safe base64 (padded or not)
+//	hex     - decode hexadecimal
+//	gunzip  - decompress gzip (up to MaxSize)
+//	trim    - remove leading and trailing white space
+//
+// All white space is removed before decoding b64, b64url, and hex
+// so that wrapped or newline-terminated data may be used. Malformed
+// input is an error.
+//
+// Modifiers are always applied in order from left to right, each to
+// the output of the one before (ex: env.b64.trim:KUBE_TOKEN decodes
+// and then trims, file.gunzip.json:data.gz#id decompresses and then
+// selects).
+//
+// # Environment variables
+//
+// The env schemas return ErrNotFound for an unset variable and
+// ErrEmptyValue for one that is set but empty. Like the shell, a default
+// may be added after :- and a custom error message after :? (ex:
+// env:TOKEN:-none, env.file:TOKEN_FILE:?must be set).
+//
+// # Configuration
+//
+// String and all the other package-level functions use the Default
+// Getter. Create a separate Getter to change the HTTP client and
+// options, ssh and scp commands, base directories, environment, file
+// system, or retry policy for a specific use.
+//
+// All local file access (file, home, conf, cache, and the line helpers
+// like [FirstLineOf]) goes through the FS of the Getter when set so
+// that an fstest.MapFS, embed.FS, or os.DirFS may be used instead of
+// the host file system.
+//
+// The netrc schema reads the file named by NETRC (if set) or ~/.netrc
+// and uses the default entry for any machine that is not listed (ex:
+// netrc:api.example.com#login). Set Netrc on a Getter to also add Basic
+// authentication from the same entries to every HTTP request.
+//
+// The TLS options of a Getter add a private CA bundle, a client
+// certificate and key for mutual TLS (each also a target, ex:
+// conf:app/ca.pem), a minimum version, a server name, and public key
+// pins to every https request (see [TLSOptions]).
+//
+// # Adding schemas
+//
+// Each of the schemas above is a built-in Fetcher that can be replaced
+// or removed and additional schemas may be added (see [Register]). All
+// of the modifiers are available for every registered schema.
+// Fetchers that can select lines more efficiently than streaming
+// everything implement [LineFetcher].
+//
 // In all cases, the source provided in the argument signature is a URL
 // of the normally expected form but with some additional schema/sources
 // mostly for convenience (ordered by simplest to most complex)
 //
 // The detection of a special URL source string is done by identifying
-// any of the reserved schema type combinations up to the first colon.
-// Therefore, use of this package where colons might be value string
+// any registered schema (and valid modifiers) up to the first colon
+// (see [Target] for the full grammar and [ParseTarget] to validate
+// a target). Any target without a registered schema is used as
+// a plain string. A registered schema with an unknown or invalid
+// modifier (ex: file.haed:/etc/hostname) is an ErrInvalidURI error
+// rather than a plain string so that a typo is never mistaken for the
+// data. Therefore, use of this package where colons might be value string
 // values should be used with caution.
 //
+// # Expansion
+//
+// Targets are used literally unless ExpandValues is set on the Getter,
+// in which case ${VAR} and a leading ~ (except for home, conf, and
+// cache) are expanded in the value (ex:
+// https://vault.local/${ENV}/token). To instead fetch targets embedded
+// within a larger string use [Expand] (ex: token={{get "conf:app/token"}}).
+//
+// # Fallbacks
+//
+// Multiple targets may be separated by || to try each in order until
+// one succeeds with a non-empty result (ex:
+// env:TOKEN||conf.head:app/token||https://team.example.com/token).
+// Only targets that are not found or empty are skipped; any other
+// error stops the chain. Every target must start with a registered
+// source. A || that is not followed by one remains a part of the
+// value or selector before it (ex: file.re:x.txt#(foo||bar)) and
+// plain strings containing || are left alone. Therefore, a plain
+// default cannot end a chain. Use [First] for that instead.
+//
+// # Errors
+//
+// Every failure is returned as a [FetchError] (with a sanitized target)
+// that may be checked with errors.Is for ErrNotFound, ErrInvalidURI,
+// ErrMissingPath, ErrToolMissing, ErrEmptyValue, ErrNotScalar, and
+// ErrTooLarge or with errors.As for the underlying cause. No HTTP body,
+// ssh or scp transfer, local file, or gunzip result larger than the
+// MaxSize of the Getter (10 MiB by default) is read, except that line
+// selection from local files keeps only the selected lines. When every
+// target of a fallback fails, a [FallbackError] containing all of them
+// is returned instead.
+//
 // # Line endings
 //
-// Note that except for head and tail the line endings are always
+// Note that except for line selection the line endings are always
 // preserved if they are included. The caller must remove these if
 // needed.
 //
@@ -147,181 +260,76 @@ func Schema(a string) (schema, value string) {
 // installed (openssh, for example) automatically have scp installed as
 // well.
 func String(target string) (string, error) {
-	schema, value := Schema(target)
-
-	// not a reserved schema, must just be a string
-	if len(schema) == 0 {
-		return target, nil
-	}
-
-	switch schema {
-
-	case `env`:
-		return os.Getenv(value), nil
-
-	case `env.head`:
-		return FirstLine(os.Getenv(value)), nil
-
-	case `env.tail`:
-		return LastLine(os.Getenv(value)), nil
-
-	case `env.file`:
-		byt, err := os.ReadFile(os.Getenv(value))
-		if err != nil {
-			return ``, err
-		}
-		return string(byt), nil
-
-	case `env.file.head`:
-		return FirstLineOf(os.Getenv(value))
-
-	case `env.file.tail`:
-		return LastLineOf(os.Getenv(value))
-
-	case `file`:
-		byt, err := os.ReadFile(value)
-		if err != nil {
-			return ``, err
-		}
-		return string(byt), nil
-
-	case `file.head`, `head`:
-		return FirstLineOf(value)
-
-	case `file.tail`, `tail`:
-		return LastLineOf(value)
-
-	case `home`:
-		byt, err := HomeFile(value)
-		if err != nil {
-			return ``, err
-		}
-		return string(byt), nil
-
-	case `home.head`:
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return ``, err
-		}
-		path := path.Join(home, value)
-		return FirstLineOf(path)
-
-	case `home.tail`:
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return ``, err
-		}
-		path := path.Join(home, value)
-		return LastLineOf(path)
-
-	case `conf`:
-		byt, err := ConfFile(value)
-		if err != nil {
-			return ``, err
-		}
-		return string(byt), nil
-
-	case `conf.head`:
-		conf, err := os.UserConfigDir()
-		if err != nil {
-			return ``, err
-		}
-		path := path.Join(conf, value)
-		return FirstLineOf(path)
-
-	case `conf.tail`:
-		conf, err := os.UserConfigDir()
-		if err != nil {
-			return ``, err
-		}
-		path := path.Join(conf, value)
-		return LastLineOf(path)
-
-	case `cache`:
-		byt, err := CacheFile(value)
-		if err != nil {
-			return ``, err
-		}
-		return string(byt), nil
-
-	case `cache.head`:
-		cache, err := os.UserCacheDir()
-		if err != nil {
-			return ``, err
-		}
-		path := path.Join(cache, value)
-		return FirstLineOf(path)
-
-	case `cache.tail`:
-		cache, err := os.UserCacheDir()
-		if err != nil {
-			return ``, err
-		}
-		path := path.Join(cache, value)
-		return LastLineOf(path)
-
-	case `scp`:
-		path, err := RemoteSCP(target, ``)
-		if err != nil {
-			return ``, err
-		}
-		path, _ = FirstFileIn(path)
-		byt, err := os.ReadFile(path)
-		return string(byt), err
-
-	case `ssh.head`:
-		return FirstLineOfSSH(target)
-
-	case `ssh.tail`:
-		return LastLineOfSSH(target)
-
-	case `http`, `https`:
-		byt, err := HTTP(target)
-		return string(byt), err
-
-	case `http.head`, `https.head`:
-		target = strings.Replace(target, `.head`, ``, 1)
-		return FirstLineOfHTTP(target)
-
-	case `http.tail`, `https.tail`:
-		target = strings.Replace(target, `.tail`, ``, 1)
-		return LastLineOfHTTP(target)
+	return Default.String(target)
+}
 
-	}
+// StringContext is the same as String but passes the context to the
+// Fetcher so that cancellation or a deadline aborts any in-flight
+// HTTP request and kills any spawned ssh or scp command.
+func StringContext(ctx context.Context, target string) (string, error) {
+	return Default.StringContext(ctx, target)
+}
 
-	// should never get here, but whatever
-	return target, nil
+// Bytes is the same as String but returns the data as is without
+// conversion to a string making it suitable for binary data (NUL
+// bytes, invalid UTF-8, etc.) from any schema. See Open to avoid
+// buffering the data into memory.
+func Bytes(target string) ([]byte, error) {
+	return Default.Bytes(target)
+}
 
+// BytesContext is the same as Bytes but with a context (see
+// StringContext).
+func BytesContext(ctx context.Context, target string) ([]byte, error) {
+	return Default.BytesContext(ctx, target)
 }
 
 // HomeFile returns the []byte content of a file within the
-// os.UserHomeDir.
+// os.UserHomeDir (or the HomeDir of the Default Getter).
 func HomeFile(relpath string) ([]byte, error) {
-	dir, err := os.UserHomeDir()
+	return HomeFileContext(context.Background(), relpath)
+}
+
+// HomeFileContext is the same as HomeFile but returns the context error
+// without reading if the context is already done.
+func HomeFileContext(ctx context.Context, relpath string) ([]byte, error) {
+	dir, err := Default.homeDir()
 	if err != nil {
 		return nil, err
 	}
-	return os.ReadFile(path.Join(dir, relpath))
+	return Default.readFile(ctx, path.Join(dir, relpath))
 }
 
 // CacheFile returns the []byte content of a file within the
-// os.UserCacheDir.
+// os.UserCacheDir (or the CacheDir of the Default Getter).
 func CacheFile(relpath string) ([]byte, error) {
-	dir, err := os.UserCacheDir()
+	return CacheFileContext(context.Background(), relpath)
+}
+
+// CacheFileContext is the same as CacheFile but returns the context
+// error without reading if the context is already done.
+func CacheFileContext(ctx context.Context, relpath string) ([]byte, error) {
+	dir, err := Default.cacheDir()
 	if err != nil {
 		return nil, err
 	}
-	return os.ReadFile(path.Join(dir, relpath))
+	return Default.readFile(ctx, path.Join(dir, relpath))
 }
 
 // ConfFile returns the []byte content of a file within the
-// os.UserConfigDir.
+// os.UserConfigDir (or the ConfDir of the Default Getter).
 func ConfFile(relpath string) ([]byte, error) {
-	dir, err := os.UserConfigDir()
+	return ConfFileContext(context.Background(), relpath)
+}
+
+// ConfFileContext is the same as ConfFile but returns the context error
+// without reading if the context is already done.
+func ConfFileContext(ctx context.Context, relpath string) ([]byte, error) {
+	dir, err := Default.confDir()
 	if err != nil {
 		return nil, err
 	}
-	return os.ReadFile(path.Join(dir, relpath))
+	return Default.readFile(ctx, path.Join(dir, relpath))
 }
 
 // FirstLine returns the first line of the string or []byte (similar to
@@ -335,15 +343,15 @@ func FirstLine[T string | []byte](a T) string {
 
 // FirstLineOf returns the first line (ending in \r?\n) of file at path
 // without buffering the entire file.
+// The FS of the Default Getter is used if set.
 func FirstLineOf(path string) (string, error) {
-	f, err := os.Open(path)
-	defer f.Close()
-	if err != nil {
-		return ``, err
-	}
-	s := bufio.NewScanner(f)
-	s.Scan()
-	return s.Text(), nil
+	return FirstLineOfContext(context.Background(), path)
+}
+
+// FirstLineOfContext is the same as FirstLineOf but returns the context
+// error without reading if the context is already done.
+func FirstLineOfContext(ctx context.Context, path string) (string, error) {
+	return Default.firstLineOf(ctx, path)
 }
 
 // LastLine returns the last line of the string or []byte without
@@ -360,18 +368,15 @@ func LastLine[T string | []byte](a T) string {
 
 // LastLineOf returns the last line (ending in \r?\n) of file at path
 // without buffering the entire file.
+// The FS of the Default Getter is used if set.
 func LastLineOf(path string) (string, error) {
-	f, err := os.Open(path)
-	defer f.Close()
-	if err != nil {
-		return ``, err
-	}
-	s := bufio.NewScanner(f)
-	var prev string
-	for s.Scan() {
-		prev = s.Text()
-	}
-	return prev, nil
+	return LastLineOfContext(context.Background(), path)
+}
+
+// LastLineOfContext is the same as LastLineOf but stops reading and
+// returns the context error as soon as the context is done.
+func LastLineOfContext(ctx context.Context, path string) (string, error) {
+	return Default.lastLineOf(ctx, path)
 }
 
 // RemoteSCP copies one or more remote files from the remote target into
@@ -385,36 +390,30 @@ func LastLineOf(path string) (string, error) {
 // recursive directory copies. Returns an error if the scp command
 // cannot be found.
 func RemoteSCP(from, to string) (string, error) {
+	return RemoteSCPContext(context.Background(), from, to)
+}
 
-	scpexe, err := exec.LookPath(`scp`)
-	if err != nil {
-		return to, err
-	}
-
-	if len(to) == 0 {
-		to, err = os.MkdirTemp(``, `scp`)
-
-		if err != nil {
-			return to, err
-		}
-	}
-
-	err = exec.Command(scpexe, `-r`, from, to).Run()
-	return to, err
+// RemoteSCPContext is the same as RemoteSCP but kills the scp command
+// if the context is done before it completes.
+func RemoteSCPContext(ctx context.Context, from, to string) (string, error) {
+	return Default.RemoteSCPContext(ctx, from, to)
 }
 
 // FirstLineOfSSH returns only the first line of a remote file by
 // calling head on the file over an ssh connection. Otherwise, identical
 // to LastLineOfSSH. See ParseSSHURI for details.
 func FirstLineOfSSH(target string) (string, error) {
-	u := ParseSSHURI(target)
-	if u == nil {
-		return ``, fmt.Errorf(`%q is not a valid SSH URI`, target)
-	}
-	if len(u.Path) == 0 {
-		return ``, fmt.Errorf(`%q is missing a file path`, target)
+	return FirstLineOfSSHContext(context.Background(), target)
+}
+
+// FirstLineOfSSHContext is the same as FirstLineOfSSH but kills the ssh
+// command if the context is done before it completes.
+func FirstLineOfSSHContext(ctx context.Context, target string) (string, error) {
+	u, err := parseSSHPath(target)
+	if err != nil {
+		return ``, err
 	}
-	return SSHOut(u.Addr, `head -1 `+u.Path)
+	return SSHOutContext(ctx, u.Addr, `head -1 `+shellPath(u.Path))
 }
 
 // SSHURI is more restrictive than SSH might allow and includes the
@@ -497,6 +496,30 @@ func ParseSSHURI(target string) *SSHURI {
 	return uri
 }
 
+// parseSSHPath is ParseSSHURI but returns an ErrInvalidURI or
+// ErrMissingPath FetchError if the target has no host or no path.
+func parseSSHPath(target string) (*SSHURI, error) {
+	u := ParseSSHURI(target)
+	if u == nil {
+		return nil, &FetchError{Op: `parse`, Target: Sanitize(target), Err: ErrInvalidURI}
+	}
+	if len(u.Path) == 0 {
+		return nil, &FetchError{Op: `parse`, Target: Sanitize(target), Err: ErrMissingPath}
+	}
+	return u, nil
+}
+
+// shellPath returns the path single-quoted for the remote shell so
+// that nothing in it is interpreted (ex: ; or $(...)) except a leading
+// ~/ for the home directory.
+func shellPath(path string) string {
+	var home string
+	if strings.HasPrefix(path, `~/`) {
+		home, path = `~/`, path[2:]
+	}
+	return home + `'` + strings.ReplaceAll(path, `'`, `'\''`) + `'`
+}
+
 // LastLineOfSSH returns the last line of a remote file by calling tail
 // on the file at the path indicated making it safe for grabbing
 // exactly one last line of a large remote file (log, etc.).
@@ -506,14 +529,17 @@ func ParseSSHURI(target string) *SSHURI {
 // relative to the login home directory or fully qualified (beginning
 // with slash). See ParseSSHURI for details.
 func LastLineOfSSH(target string) (string, error) {
-	u := ParseSSHURI(target)
-	if u == nil {
-		return ``, fmt.Errorf(`%q is not a valid SSH URI`, target)
-	}
-	if len(u.Path) == 0 {
-		return ``, fmt.Errorf(`%q is missing a file path`, target)
+	return LastLineOfSSHContext(context.Background(), target)
+}
+
+// LastLineOfSSHContext is the same as LastLineOfSSH but kills the ssh
+// command if the context is done before it completes.
+func LastLineOfSSHContext(ctx context.Context, target string) (string, error) {
+	u, err := parseSSHPath(target)
+	if err != nil {
+		return ``, err
 	}
-	return SSHOut(u.Addr, `tail -1 `+u.Path)
+	return SSHOutContext(ctx, u.Addr, `tail -1 `+shellPath(u.Path))
 }
 
 // SSHOut sends the command string to the target using the ssh command
@@ -527,57 +553,73 @@ func LastLineOfSSH(target string) (string, error) {
 // //user@localhost:22). See the documentation on the ssh command itself
 // for more details.
 func SSHOut(target, command string) (string, error) {
-	sshexe, err := exec.LookPath(`ssh`)
-	if err != nil {
-		return ``, err
-	}
-	byt, err := exec.Command(sshexe, target, command).Output()
-	return string(byt), err
+	return SSHOutContext(context.Background(), target, command)
+}
+
+// SSHOutContext is the same as SSHOut but kills the ssh command if the
+// context is done before it completes.
+func SSHOutContext(ctx context.Context, target, command string) (string, error) {
+	return Default.SSHOutContext(ctx, target, command)
 }
 
 // FirstFileIn returns the full path to the first file in the specified
 // local directory (excluding directories, which are technically also
-// "files").
+// "files"). Returns ErrNotFound if there are no files.
 func FirstFileIn(dir string) (string, error) {
 	files, err := os.ReadDir(dir)
 	if err != nil {
-		return ``, err
+		return ``, fetchErr(`read`, dir, err)
 	}
 	for _, file := range files {
 		if !file.IsDir() {
 			return path.Join(dir, file.Name()), nil
 		}
 	}
-	return ``, fmt.Errorf(`file not found`)
+	return ``, &FetchError{Op: `read`, Target: dir, Err: ErrNotFound}
 }
 
 // HTTP returns the full content of the response to the target (url).
-// TLS is supported. Internally the net/http.DefualtClient is used.
+// TLS is supported. Internally the Client of the Default Getter is used
+// (net/http.DefaultClient unless changed). Any response status outside
+// of the 2xx range returns an HTTPError (wrapped in a FetchError) unless
+// it is one of the AcceptStatus codes of the Default Getter. The method,
+// headers, body, and authentication may be set with the HTTP options of
+// the Default Getter (see HTTPOptions) and the certificates and
+// versions trusted with its TLS options (see TLSOptions).
 func HTTP(url string) ([]byte, error) {
-	resp, err := http.DefaultClient.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	return io.ReadAll(resp.Body)
+	return HTTPContext(context.Background(), url)
+}
+
+// HTTPContext is the same as HTTP but the request (including reading
+// the body) is aborted if the context is done before it completes.
+func HTTPContext(ctx context.Context, url string) ([]byte, error) {
+	return Default.HTTPContext(ctx, url)
 }
 
-// FirstLineOfHTTP fetches the entire content at the given URL and
-// returns the first line of it.
+// FirstLineOfHTTP returns the first line of the content at the given
+// URL reading the response body only up to the end of that line.
 func FirstLineOfHTTP(url string) (string, error) {
-	byt, err := HTTP(url)
-	if err != nil {
-		return ``, err
-	}
-	return FirstLine(byt), nil
+	return FirstLineOfHTTPContext(context.Background(), url)
+}
+
+// FirstLineOfHTTPContext is the same as FirstLineOfHTTP but with
+// a context (see HTTPContext).
+func FirstLineOfHTTPContext(ctx context.Context, url string) (string, error) {
+	return Default.lineOfHTTP(ctx, url, Lines{1, 1})
 }
 
-// LastLineOfHTTP fetches the entire content at the given URL and
-// returns the last line of it.
+// LastLineOfHTTP returns the last line of the content at the given URL.
+// A HEAD request is made first to check the Content-Length and
+// Accept-Ranges. When ranges are supported, only the end of the content
+// is requested (Range: bytes=-N) with N growing until the last line is
+// complete. Otherwise, the full content is streamed without buffering
+// more than the last line read.
 func LastLineOfHTTP(url string) (string, error) {
-	byt, err := HTTP(url)
-	if err != nil {
-		return ``, err
-	}
-	return LastLine(byt), nil
+	return LastLineOfHTTPContext(context.Background(), url)
+}
+
+// LastLineOfHTTPContext is the same as LastLineOfHTTP but with
+// a context (see HTTPContext).
+func LastLineOfHTTPContext(ctx context.Context, url string) (string, error) {
+	return Default.lineOfHTTP(ctx, url, Lines{-1, -1})
 }
@@ -4,16 +4,42 @@
 package get_test
 
 import (
+	"context"
+	"embed"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
+	"testing/fstest"
+	"time"
 
 	"github.com/rwxrob/get"
 )
 
+// fakeUserDirs points the home, conf, and cache directories of the
+// Default Getter at an in-memory file system each containing a .some
+// file rather than depending on (or writing into) the real ones. The
+// returned function restores the originals.
+func fakeUserDirs() func() {
+	orig := *get.Default
+	some := &fstest.MapFile{Data: []byte("first line\nsecond line\nlast line\n")}
+	get.Default.FS = fstest.MapFS{
+		`home/.some`:  some,
+		`conf/.some`:  some,
+		`cache/.some`: some,
+	}
+	get.Default.HomeDir = `/home`
+	get.Default.ConfDir = `/conf`
+	get.Default.CacheDir = `/cache`
+	return func() { *get.Default = orig }
+}
+
+//go:embed testdata/datafile
+var embedded embed.FS
+
 func ExampleString_env() {
 	os.Setenv(`FOO`, `something`)
 	defer os.Unsetenv(`FOO`)
@@ -156,6 +182,7 @@ func ExampleString_file_tail() {
 }
 
 func ExampleString_home() {
+	defer fakeUserDirs()()
 
 	it, err := get.String(`home:.some`)
 	if err != nil {
@@ -171,6 +198,7 @@ func ExampleString_home() {
 }
 
 func ExampleString_home_head() {
+	defer fakeUserDirs()()
 
 	it, err := get.String(`home.head:.some`)
 	if err != nil {
@@ -184,6 +212,7 @@ func ExampleString_home_head() {
 }
 
 func ExampleString_home_tail() {
+	defer fakeUserDirs()()
 
 	it, err := get.String(`home.tail:.some`)
 	if err != nil {
@@ -197,6 +226,7 @@ func ExampleString_home_tail() {
 }
 
 func ExampleString_conf() {
+	defer fakeUserDirs()()
 
 	it, err := get.String(`conf:.some`)
 	if err != nil {
@@ -212,6 +242,7 @@ func ExampleString_conf() {
 }
 
 func ExampleString_conf_head() {
+	defer fakeUserDirs()()
 
 	it, err := get.String(`conf.head:.some`)
 	if err != nil {
@@ -225,6 +256,7 @@ func ExampleString_conf_head() {
 }
 
 func ExampleString_conf_tail() {
+	defer fakeUserDirs()()
 
 	it, err := get.String(`conf.tail:.some`)
 	if err != nil {
@@ -238,6 +270,7 @@ func ExampleString_conf_tail() {
 }
 
 func ExampleString_cache() {
+	defer fakeUserDirs()()
 
 	it, err := get.String(`cache:.some`)
 	if err != nil {
@@ -253,6 +286,7 @@ func ExampleString_cache() {
 }
 
 func ExampleString_cache_head() {
+	defer fakeUserDirs()()
 
 	it, err := get.String(`cache.head:.some`)
 	if err != nil {
@@ -266,6 +300,7 @@ func ExampleString_cache_head() {
 }
 
 func ExampleString_cache_tail() {
+	defer fakeUserDirs()()
 
 	it, err := get.String(`cache.tail:.some`)
 	if err != nil {
@@ -278,6 +313,21 @@ func ExampleString_cache_tail() {
 	// last line
 }
 
+func ExampleString_embed() {
+	get.Default.Embed = embedded
+	defer func() { get.Default.Embed = nil }()
+
+	it, err := get.String(`embed.tail:testdata/datafile`)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(it)
+
+	// Output:
+	// last line
+}
+
 func ExampleString_scp() {
 
 	it, err := get.String(`scp://localhost/somefile.txt`)
@@ -397,6 +447,25 @@ func ExampleString_https() {
 	fmt.Println(it)
 }
 
+func ExampleStringContext() {
+
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done() // never responds
+		})
+	svr := httptest.NewServer(handler)
+	defer svr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := get.StringContext(ctx, svr.URL)
+	fmt.Println(errors.Is(err, context.DeadlineExceeded))
+
+	// Output:
+	// true
+}
+
 func ExampleSchema_values_Only() {
 
 	valid := []string{
@@ -827,6 +896,25 @@ func ExampleHTTP() {
 	// last line
 }
 
+func ExampleHTTPContext() {
+
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "first line\nsecond line\nlast line\n")
+		})
+	svr := httptest.NewServer(handler)
+	defer svr.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := get.HTTPContext(ctx, svr.URL)
+	fmt.Println(errors.Is(err, context.Canceled))
+
+	// Output:
+	// true
+}
+
 func ExampleFirstLineOfHTTP() {
 
 	handler := http.HandlerFunc(
@@ -864,3 +952,28 @@ func ExampleLastLineOfHTTP() {
 	// Output:
 	// last line
 }
+
+func ExampleString_env_strict() {
+	os.Setenv(`EMPTY`, ``)
+	defer os.Unsetenv(`EMPTY`)
+	os.Unsetenv(`UNSET`)
+
+	_, err := get.String(`env:UNSET`)
+	fmt.Println(errors.Is(err, get.ErrNotFound))
+
+	_, err = get.String(`env:EMPTY`)
+	fmt.Println(errors.Is(err, get.ErrEmptyValue))
+
+	fmt.Println(get.String(`env:UNSET:-some default`))
+	fmt.Println(get.String(`env:EMPTY:-some default`))
+
+	_, err = get.String(`env.file:UNSET:?set UNSET to the token file`)
+	fmt.Println(err)
+
+	// Output:
+	// true
+	// true
+	// some default <nil>
+	// some default <nil>
+	// get: env.file: env UNSET: not found: set UNSET to the token file
+}
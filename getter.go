@@ -0,0 +1,536 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+// Getter holds everything that would otherwise be hard-wired when
+// fetching a target allowing isolated configurations to be used side by
+// side (in tests or multi-tenant services, for example). The zero value
+// uses the same defaults as the package-level functions, which are all
+// thin wrappers around the Default Getter. A Getter must not be
+// modified while in use.
+type Getter struct {
+
+	// Client is used for the http and https schemas. When nil,
+	// net/http.DefaultClient is used.
+	Client *http.Client
+
+	// SSHPath and SCPPath are the paths to the ssh and scp executables.
+	// When empty, each is looked up in the PATH.
+	SSHPath string
+	SCPPath string
+
+	// SSHArgs and SCPArgs are added to every ssh and scp command before
+	// the target (ex: -o BatchMode=yes).
+	SSHArgs []string
+	SCPArgs []string
+
+	// HomeDir, ConfDir, and CacheDir are the base directories for the
+	// home, conf, and cache schemas. When empty, os.UserHomeDir,
+	// os.UserConfigDir, and os.UserCacheDir are used.
+	HomeDir  string
+	ConfDir  string
+	CacheDir string
+
+	// LookupEnv is used for all environment variable lookups. When nil,
+	// os.LookupEnv is used.
+	LookupEnv func(key string) (string, bool)
+
+	// AcceptStatus are the non-2xx HTTP status codes that are to be
+	// accepted as successful (returning the body) rather than returning
+	// an HTTPError.
+	AcceptStatus []int
+
+	// MaxSize is the maximum number of bytes that will be read from any
+	// HTTP response body, file copied with scp, ssh output, gunzip
+	// result, or local file (but not line selection from local files
+	// such as file.tail or LastLineOf, which only keeps the selected
+	// lines in memory). Anything larger returns a TooLargeError as soon
+	// as it is known (from Content-Length or the size of the file, for
+	// example). When zero, DefaultMaxSize is used. A negative MaxSize
+	// has no limit.
+	MaxSize int64
+
+	// Retry determines if and when failed http, https, ssh, and scp
+	// fetches are tried again (see RetryPolicy). The zero value never
+	// retries.
+	Retry RetryPolicy
+
+	// HTTP customizes the method, headers, body, and authentication of
+	// every HTTP request (see HTTPOptions).
+	HTTP HTTPOptions
+
+	// TLS configures the certificate authorities, client certificate,
+	// minimum version, server name, and public key pins of every https
+	// request (see TLSOptions).
+	TLS TLSOptions
+
+	// Netrc adds Basic authentication to every http and https request
+	// with the login and password for the host from the netrc file
+	// (NETRC or ~/.netrc, see the netrc schema) unless the URL or HTTP
+	// already contain credentials. Hosts without an entry (or default) are
+	// requested without authentication.
+	Netrc bool
+
+	// ExpandValues replaces every ${VAR} in the value portion of each
+	// target (after the colon and before any #selector) with the value
+	// of the environment variable and a leading ~ with the home
+	// directory before fetching (ex: file:${XDG_RUNTIME_DIR}/app/token,
+	// file:~/.token). The ~ is not expanded for home, conf, and cache,
+	// which are already relative to a directory (ex: home:.token). An
+	// unset variable is an ErrNotFound error. Plain strings are never
+	// expanded.
+	ExpandValues bool
+
+	// FS is used for all local file access. When nil, the host file
+	// system is used directly (os.Open). Otherwise, paths are cleaned
+	// and any leading slash removed so that both absolute and relative
+	// paths are relative to the root of FS. Any fs.FS will work
+	// including fstest.MapFS, embed.FS, and os.DirFS (which acts like
+	// a chroot).
+	FS fs.FS
+
+	// Embed is the file system used by the embed schema, usually an
+	// embed.FS so that default data can ship inside the binary. Paths
+	// are handled the same as for FS.
+	Embed fs.FS
+}
+
+// Default is the Getter used by all of the package-level functions.
+var Default = new(Getter)
+
+// DefaultMaxSize is the MaxSize used by any Getter without one (10 MiB).
+const DefaultMaxSize = 10 << 20
+
+// String is the same as the package String function but uses the
+// configuration of the Getter.
+func (g *Getter) String(target string) (string, error) {
+	return g.StringContext(context.Background(), target)
+}
+
+// StringContext is the same as the package StringContext function but
+// uses the configuration of the Getter.
+func (g *Getter) StringContext(ctx context.Context, target string) (string, error) {
+	byt, err := g.BytesContext(ctx, target)
+	return string(byt), err
+}
+
+// Bytes is the same as the package Bytes function but uses the
+// configuration of the Getter.
+func (g *Getter) Bytes(target string) ([]byte, error) {
+	return g.BytesContext(context.Background(), target)
+}
+
+// BytesContext is the same as the package BytesContext function but
+// uses the configuration of the Getter.
+func (g *Getter) BytesContext(ctx context.Context, target string) ([]byte, error) {
+	rc, err := g.OpenContext(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// HTTPContext is the same as the package HTTPContext function but uses
+// the Client, HTTP options, and AcceptStatus of the Getter.
+func (g *Getter) HTTPContext(ctx context.Context, url string) ([]byte, error) {
+	body, err := g.openHTTP(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	byt, err := io.ReadAll(body)
+	return byt, fetchErr(`http`, url, err)
+}
+
+// lineOfHTTP returns the selected lines from the streamed body or, for
+// negative selections, from the end of the body (see tailOfHTTP).
+func (g *Getter) lineOfHTTP(ctx context.Context, url string, sel Lines) (string, error) {
+	if sel.Start < 0 {
+		return g.tailOfHTTP(ctx, url, sel)
+	}
+	return g.streamLinesOfHTTP(ctx, url, sel)
+}
+
+// openHTTP returns the body of a successful request to the url (see
+// HTTPOptions) limited to MaxSize.
+func (g *Getter) openHTTP(ctx context.Context, url string) (io.ReadCloser, error) {
+	f, err := g.newHTTPFetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	body, err := f.open(ctx)
+	if err != nil {
+		f.done()
+		return nil, err
+	}
+	return &doneCloser{body, f.done}, nil
+}
+
+// SSHOutContext is the same as the package SSHOutContext function but
+// uses the SSHPath and SSHArgs of the Getter. Output larger than the
+// MaxSize of the Getter returns a TooLargeError.
+func (g *Getter) SSHOutContext(ctx context.Context, target, command string) (string, error) {
+	sshexe, err := g.exe(g.SSHPath, `ssh`)
+	if err != nil {
+		return ``, err
+	}
+	args := append(append([]string{}, g.SSHArgs...), target, command)
+	var out string
+	err = g.retry(ctx, func() error {
+		stdout := &limitBuffer{limit: g.maxSize()}
+		stderr := new(bytes.Buffer)
+		cmd := exec.CommandContext(ctx, sshexe, args...)
+		cmd.Stdout, cmd.Stderr = stdout, stderr
+		err := cmd.Run()
+		if stdout.err != nil {
+			// the remote command may also fail from the closed output
+			return fetchErr(`ssh`, target, stdout.err)
+		}
+		out = stdout.String()
+		return fetchErr(`ssh`, target, withStderr(err, stderr.Bytes()))
+	})
+	return out, err
+}
+
+// sshPipe starts the command on the target over ssh returning a stream
+// of its output. Only failures before any output has been received are
+// retried (see RetryPolicy) since the output cannot be taken back.
+func (g *Getter) sshPipe(ctx context.Context, target, command string) (io.ReadCloser, error) {
+	sshexe, err := g.exe(g.SSHPath, `ssh`)
+	if err != nil {
+		return nil, err
+	}
+	args := append(append([]string{}, g.SSHArgs...), target, command)
+	if g.Retry.MaxAttempts <= 1 {
+		return startCmd(exec.CommandContext(ctx, sshexe, args...), `ssh`, target)
+	}
+	var rc io.ReadCloser
+	err = g.retry(ctx, func() error {
+		r, err := startCmd(exec.CommandContext(ctx, sshexe, args...), `ssh`, target)
+		if err != nil {
+			return err
+		}
+		// wait for the first output (or failure) before committing
+		br := bufio.NewReader(r)
+		if _, err := br.Peek(1); err != nil && err != io.EOF {
+			r.Close()
+			return err
+		}
+		rc = struct {
+			io.Reader
+			io.Closer
+		}{br, r}
+		return nil
+	})
+	return rc, err
+}
+
+// RemoteSCPContext is the same as the package RemoteSCPContext function
+// but uses the SCPPath and SCPArgs of the Getter.
+func (g *Getter) RemoteSCPContext(ctx context.Context, from, to string) (string, error) {
+
+	scpexe, err := g.exe(g.SCPPath, `scp`)
+	if err != nil {
+		return to, err
+	}
+
+	if len(to) == 0 {
+		to, err = os.MkdirTemp(``, `scp`)
+
+		if err != nil {
+			return to, fetchErr(`scp`, from, err)
+		}
+	}
+
+	args := append(append([]string{}, g.SCPArgs...), `-r`, from, to)
+	err = g.retry(ctx, func() error {
+		stderr := new(bytes.Buffer)
+		cmd := exec.CommandContext(ctx, scpexe, args...)
+		cmd.Stderr = stderr
+		err := cmd.Run()
+		return fetchErr(`scp`, from, withStderr(err, stderr.Bytes()))
+	})
+	return to, err
+}
+
+// netrcAuth sets Basic authentication from the netrc entry for the
+// host of the request when Netrc is enabled. A missing netrc file is
+// not an error.
+func (g *Getter) netrcAuth(ctx context.Context, req *http.Request) error {
+	if !g.Netrc || req.URL.User != nil {
+		return nil
+	}
+	e, err := g.netrcEntry(ctx, req.URL.Hostname())
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if e != nil && len(e.login) > 0 {
+		req.SetBasicAuth(e.login, e.password)
+	}
+	return nil
+}
+
+// maxSize returns the MaxSize of the Getter (DefaultMaxSize if zero)
+// or -1 if there is no limit.
+func (g *Getter) maxSize() int64 {
+	switch {
+	case g.MaxSize == 0:
+		return DefaultMaxSize
+	case g.MaxSize < 0:
+		return -1
+	}
+	return g.MaxSize
+}
+
+// limit returns the reader limited to the MaxSize of the Getter
+// closing it and returning a TooLargeError if the known size (zero or
+// less if unknown) is already larger.
+func (g *Getter) limit(rc io.ReadCloser, size int64) (io.ReadCloser, error) {
+	max := g.maxSize()
+	if max < 0 {
+		return rc, nil
+	}
+	if size > max {
+		rc.Close()
+		return nil, &TooLargeError{Limit: max, Size: size}
+	}
+	return &limitReader{ReadCloser: rc, limit: max}, nil
+}
+
+// limitReader returns a TooLargeError as soon as more than limit bytes
+// have been read.
+type limitReader struct {
+	io.ReadCloser
+	limit int64
+	n     int64
+}
+
+func (r *limitReader) Read(p []byte) (int, error) {
+	// read one more than the limit to know if there is more
+	if max := r.limit - r.n + 1; int64(len(p)) > max {
+		p = p[:max]
+	}
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	if r.n > r.limit {
+		return n - int(r.n-r.limit), &TooLargeError{Limit: r.limit}
+	}
+	return n, err
+}
+
+// limitBuffer keeps everything written to it until more than limit
+// bytes (unless negative) have been written, after which every write
+// fails with a TooLargeError. (The buffer is not embedded so that its
+// ReadFrom cannot be used by io.Copy to skip the limit.)
+type limitBuffer struct {
+	buf   bytes.Buffer
+	limit int64
+	err   error
+}
+
+func (b *limitBuffer) Write(p []byte) (int, error) {
+	if b.err == nil && b.limit >= 0 && int64(b.buf.Len()+len(p)) > b.limit {
+		b.err = &TooLargeError{Limit: b.limit}
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitBuffer) String() string { return b.buf.String() }
+
+// accepted returns true for any 2xx status or any in AcceptStatus.
+func (g *Getter) accepted(status int) bool {
+	if status >= 200 && status < 300 {
+		return true
+	}
+	for _, s := range g.AcceptStatus {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Getter) client() *http.Client {
+	if g.Client != nil {
+		return g.Client
+	}
+	return http.DefaultClient
+}
+
+func (g *Getter) exe(path, name string) (string, error) {
+	if len(path) > 0 {
+		name = path
+	}
+	exe, err := exec.LookPath(name)
+	if err != nil {
+		return ``, toolErr(name, err)
+	}
+	return exe, nil
+}
+
+func (g *Getter) lookupEnv(key string) (string, bool) {
+	if g.LookupEnv != nil {
+		return g.LookupEnv(key)
+	}
+	return os.LookupEnv(key)
+}
+
+// env returns the value of the environment variable named by value.
+// An unset variable returns ErrNotFound and an empty one ErrEmptyValue
+// unless one of the following shell-style modifiers follows the name:
+//
+//	NAME:-default  - default if unset or empty
+//	NAME:?message  - error with message if unset or empty
+func (g *Getter) env(value string) (string, error) {
+	name, mod, arg := value, ``, ``
+	if i := strings.Index(value, `:`); i >= 0 {
+		rest := value[i+1:]
+		if strings.HasPrefix(rest, `-`) || strings.HasPrefix(rest, `?`) {
+			name, mod, arg = value[:i], rest[:1], rest[1:]
+		}
+	}
+	val, set := g.lookupEnv(name)
+	if set && len(val) > 0 {
+		return val, nil
+	}
+	err := ErrEmptyValue
+	if !set {
+		err = ErrNotFound
+	}
+	switch mod {
+	case `-`:
+		return arg, nil
+	case `?`:
+		if len(arg) > 0 {
+			err = fmt.Errorf(`%w: %v`, err, arg)
+		}
+	}
+	return ``, &FetchError{Op: `env`, Target: name, Err: err}
+}
+
+func (g *Getter) homeDir() (string, error) {
+	if len(g.HomeDir) > 0 {
+		return g.HomeDir, nil
+	}
+	dir, err := os.UserHomeDir()
+	return dir, fetchErr(`dir`, ``, err)
+}
+
+func (g *Getter) confDir() (string, error) {
+	if len(g.ConfDir) > 0 {
+		return g.ConfDir, nil
+	}
+	dir, err := os.UserConfigDir()
+	return dir, fetchErr(`dir`, ``, err)
+}
+
+func (g *Getter) cacheDir() (string, error) {
+	if len(g.CacheDir) > 0 {
+		return g.CacheDir, nil
+	}
+	dir, err := os.UserCacheDir()
+	return dir, fetchErr(`dir`, ``, err)
+}
+
+// openFile opens the local file at path from FS (if set) after first
+// checking that the context is not done.
+func (g *Getter) openFile(ctx context.Context, name string) (fs.File, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fetchErr(`read`, name, err)
+	}
+	var f fs.File
+	var err error
+	if g.FS == nil {
+		f, err = os.Open(name)
+	} else {
+		f, err = g.FS.Open(fsPath(name))
+	}
+	if err != nil {
+		return nil, fetchErr(`read`, name, err)
+	}
+	return f, nil
+}
+
+// openLimited opens the local file (see openFile) limiting it to the
+// MaxSize of the Getter.
+func (g *Getter) openLimited(ctx context.Context, name string) (io.ReadCloser, error) {
+	f, err := g.openFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return g.limitFile(f, name)
+}
+
+// limitFile limits the open file to the MaxSize of the Getter failing
+// immediately if the file is already known to be larger.
+func (g *Getter) limitFile(f fs.File, name string) (io.ReadCloser, error) {
+	var size int64
+	if info, err := f.Stat(); err == nil && info.Mode().IsRegular() {
+		size = info.Size()
+	}
+	rc, err := g.limit(f, size)
+	return rc, fetchErr(`read`, name, err)
+}
+
+func (g *Getter) readFile(ctx context.Context, name string) ([]byte, error) {
+	f, err := g.openLimited(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	byt, err := io.ReadAll(f)
+	return byt, fetchErr(`read`, name, err)
+}
+
+func (g *Getter) firstLineOf(ctx context.Context, name string) (string, error) {
+	f, err := g.openFile(ctx, name)
+	if err != nil {
+		return ``, err
+	}
+	defer f.Close()
+	line, err := selectLines(ctx, f, Lines{1, 1})
+	return line, fetchErr(`read`, name, err)
+}
+
+func (g *Getter) lastLineOf(ctx context.Context, name string) (string, error) {
+	f, err := g.openFile(ctx, name)
+	if err != nil {
+		return ``, err
+	}
+	defer f.Close()
+	line, err := selectLines(ctx, f, Lines{-1, -1})
+	return line, fetchErr(`read`, name, err)
+}
+
+// fsPath converts a host path into a valid io/fs path relative to the
+// root of the file system.
+func fsPath(name string) string {
+	name = strings.TrimLeft(path.Clean(`/`+name), `/`)
+	if len(name) == 0 {
+		return `.`
+	}
+	return name
+}
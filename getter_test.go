@@ -0,0 +1,67 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"fmt"
+	"os"
+	"testing/fstest"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleGetter() {
+
+	env := map[string]string{`FOO`: `something`}
+
+	g := &get.Getter{
+		HomeDir: `testdata`,
+		LookupEnv: func(key string) (string, bool) {
+			val, has := env[key]
+			return val, has
+		},
+	}
+
+	fmt.Println(g.String(`env:FOO`))
+	fmt.Println(g.String(`home.tail:datafile`))
+
+	// Output:
+	// something <nil>
+	// last line <nil>
+}
+
+func ExampleGetter_ssh() {
+
+	g := &get.Getter{
+		SSHPath: `testdata/fakessh`,
+		SSHArgs: []string{`-o`, `BatchMode=yes`},
+	}
+
+	out, err := g.String(`ssh.head://localhost/testdata/datafile`)
+	fmt.Printf("%q %v\n", out, err)
+
+	// Output:
+	// "first line" <nil>
+}
+
+func ExampleGetter_fS() {
+
+	g := &get.Getter{
+		HomeDir: `/home/user`,
+		FS: fstest.MapFS{
+			`home/user/.token`: {Data: []byte("mytoken\n# comment\n")},
+		},
+	}
+
+	fmt.Println(g.String(`home.head:.token`))
+
+	// paths are always relative to the root of the FS
+	g = &get.Getter{FS: os.DirFS(`testdata`)}
+	fmt.Println(g.String(`file:/somefile`))
+
+	// Output:
+	// mytoken <nil>
+	// something
+	//  <nil>
+}
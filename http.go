@@ -0,0 +1,289 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// HTTPOptions customize every HTTP request made by a Getter (HTTP,
+// FirstLineOfHTTP, LastLineOfHTTP, and the http and https schemas).
+// The Bearer, Username, and Password credentials are themselves
+// targets (see String) so that they may be kept anywhere (ex:
+// conf.head:app/bootstrap). Since plain strings are returned as is,
+// a literal value also works but is not recommended. Any leading or
+// trailing white space (such as the line ending of a file) is removed
+// from each credential. Credentials are resolved once for each fetch
+// no matter how many requests it takes (retries, ranges, etc.).
+type HTTPOptions struct {
+
+	// Method is the HTTP method (GET when empty).
+	Method string
+
+	// Header is added to every request (ex: Accept, User-Agent).
+	Header http.Header
+
+	// Body is sent as the request body when not empty.
+	Body string
+
+	// Bearer is the target of a token sent as Authorization: Bearer.
+	Bearer string
+
+	// Username and Password are the targets of the credentials sent
+	// with Basic authentication when Username is not empty.
+	Username string
+	Password string
+}
+
+// newHTTPRequest returns a new request to the url with all of the
+// HTTPOptions of the Getter applied (including any netrc Basic
+// authentication if no other is set).
+func (g *Getter) newHTTPRequest(ctx context.Context, url string) (*http.Request, error) {
+	opts := g.HTTP
+	method := opts.Method
+	if len(method) == 0 {
+		method = `GET`
+	}
+	var req *http.Request
+	var err error
+	if len(opts.Body) > 0 {
+		req, err = http.NewRequestWithContext(ctx, method, url, strings.NewReader(opts.Body))
+	} else {
+		req, err = http.NewRequestWithContext(ctx, method, url, nil)
+	}
+	if err != nil {
+		return nil, fetchErr(`http`, url, fmt.Errorf(`%w: %v`, ErrInvalidURI, err))
+	}
+	for k, v := range opts.Header {
+		req.Header[k] = append([]string{}, v...)
+	}
+
+	switch {
+	case len(opts.Bearer) > 0:
+		token, err := g.credential(ctx, opts.Bearer)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(`Authorization`, `Bearer `+token)
+	case len(opts.Username) > 0:
+		user, err := g.credential(ctx, opts.Username)
+		if err != nil {
+			return nil, err
+		}
+		pass, err := g.credential(ctx, opts.Password)
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(user, pass)
+	}
+
+	if len(req.Header.Get(`Authorization`)) == 0 {
+		if err := g.netrcAuth(ctx, req); err != nil {
+			return nil, fetchErr(`http`, url, err)
+		}
+	}
+	return req, nil
+}
+
+// httpFetch is a single top-level fetch from a url, which may take
+// several requests (retries, HEAD, and ranges). The client and the
+// credentials (see HTTPOptions and TLSOptions) are resolved only once
+// and every request is a clone of the first.
+type httpFetch struct {
+	g      *Getter
+	url    string
+	client *http.Client
+	req    *http.Request
+}
+
+// newHTTPFetch resolves the client and credentials for a fetch from the
+// url. Call done when finished.
+func (g *Getter) newHTTPFetch(ctx context.Context, url string) (*httpFetch, error) {
+	client, err := g.httpClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req, err := g.newHTTPRequest(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return &httpFetch{g: g, url: url, client: client, req: req}, nil
+}
+
+// done closes any idle connections of a client created for the fetch
+// (see TLSOptions).
+func (f *httpFetch) done() {
+	if !f.g.TLS.isZero() {
+		f.client.CloseIdleConnections()
+	}
+}
+
+// do sends a copy of the request after passing it to prep (if not nil)
+// and returns the response if the status is accepted. Failures are
+// retried (see RetryPolicy).
+func (f *httpFetch) do(ctx context.Context, prep func(*http.Request)) (*http.Response, error) {
+	var resp *http.Response
+	err := f.g.retry(ctx, func() error {
+		req := f.req.Clone(ctx)
+		if f.req.GetBody != nil {
+			body, err := f.req.GetBody()
+			if err != nil {
+				return fetchErr(`http`, f.url, err)
+			}
+			req.Body = body
+		}
+		if prep != nil {
+			prep(req)
+		}
+		res, err := f.client.Do(req)
+		if err != nil {
+			return fetchErr(`http`, f.url, err)
+		}
+		if !f.g.accepted(res.StatusCode) {
+			defer res.Body.Close()
+			return fetchErr(`http`, f.url, newHTTPError(res))
+		}
+		resp = res
+		return nil
+	})
+	return resp, err
+}
+
+// open returns the body of a successful request limited to MaxSize.
+func (f *httpFetch) open(ctx context.Context) (io.ReadCloser, error) {
+	resp, err := f.do(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := f.g.limit(resp.Body, resp.ContentLength)
+	return body, fetchErr(`http`, f.url, err)
+}
+
+// lines returns the selected lines from the full body.
+func (f *httpFetch) lines(ctx context.Context, sel Lines) (string, error) {
+	body, err := f.open(ctx)
+	if err != nil {
+		return ``, err
+	}
+	defer body.Close()
+	line, err := selectLines(ctx, body, sel)
+	return line, fetchErr(`http`, f.url, err)
+}
+
+// doneCloser calls done after closing.
+type doneCloser struct {
+	io.ReadCloser
+	done func()
+}
+
+func (c *doneCloser) Close() error {
+	err := c.ReadCloser.Close()
+	c.done()
+	return err
+}
+
+// credential returns the trimmed data from the target without using
+// the HTTPOptions or TLSOptions (so that an https target cannot
+// recurse).
+func (g *Getter) credential(ctx context.Context, target string) (string, error) {
+	cg := *g
+	cg.HTTP = HTTPOptions{}
+	cg.TLS = TLSOptions{}
+	val, err := cg.StringContext(ctx, target)
+	return strings.TrimSpace(val), err
+}
+
+// tailChunk is the size of the first suffix range requested by
+// tailOfHTTP, which grows by four times with each request after.
+const tailChunk = 4096
+
+// tailOfHTTP returns the selected lines (counted from the end) by first
+// asking for the Content-Length and Accept-Ranges with HEAD and then
+// requesting growing suffix ranges (Range: bytes=-N) until enough
+// complete lines have been received. The full body is streamed instead
+// when ranges are not supported or the method is not GET.
+func (g *Getter) tailOfHTTP(ctx context.Context, url string, sel Lines) (string, error) {
+	f, err := g.newHTTPFetch(ctx, url)
+	if err != nil {
+		return ``, err
+	}
+	defer f.done()
+	if m := g.HTTP.Method; len(m) > 0 && m != `GET` {
+		return f.lines(ctx, sel)
+	}
+	head, err := f.do(ctx, func(req *http.Request) {
+		req.Method = `HEAD`
+		req.Body, req.GetBody, req.ContentLength = nil, nil, 0
+	})
+	if err != nil {
+		return f.lines(ctx, sel)
+	}
+	head.Body.Close()
+	size := head.ContentLength
+	if head.Header.Get(`Accept-Ranges`) != `bytes` || size <= tailChunk {
+		return f.lines(ctx, sel)
+	}
+
+	for n := int64(tailChunk); ; n *= 4 {
+		resp, err := f.do(ctx, func(req *http.Request) {
+			req.Header.Set(`Range`, fmt.Sprintf(`bytes=-%v`, n))
+		})
+		if err != nil {
+			return ``, err
+		}
+		body, err := g.limit(resp.Body, resp.ContentLength)
+		if err != nil {
+			return ``, fetchErr(`http`, url, err)
+		}
+		if resp.StatusCode != http.StatusPartialContent {
+			// range ignored, this is the full body
+			defer body.Close()
+			line, err := selectLines(ctx, body, sel)
+			return line, fetchErr(`http`, url, err)
+		}
+		byt, err := io.ReadAll(body)
+		body.Close()
+		if err != nil {
+			return ``, fetchErr(`http`, url, err)
+		}
+		whole := n >= size
+		if !whole {
+			// the first line is most likely only part of one
+			i := bytes.IndexByte(byt, '\n')
+			if i < 0 {
+				continue
+			}
+			byt = byt[i+1:]
+		}
+		if whole || countLines(byt) >= -sel.Start {
+			line, err := selectLines(ctx, bytes.NewReader(byt), sel)
+			return line, fetchErr(`http`, url, err)
+		}
+	}
+}
+
+// streamLinesOfHTTP returns the selected lines from the full body.
+func (g *Getter) streamLinesOfHTTP(ctx context.Context, url string, sel Lines) (string, error) {
+	f, err := g.newHTTPFetch(ctx, url)
+	if err != nil {
+		return ``, err
+	}
+	defer f.done()
+	return f.lines(ctx, sel)
+}
+
+// countLines returns the number of lines in the data the same as
+// selectLines would count them.
+func countLines(byt []byte) int {
+	n := bytes.Count(byt, []byte{'\n'})
+	if len(byt) > 0 && byt[len(byt)-1] != '\n' {
+		n++
+	}
+	return n
+}
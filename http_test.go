@@ -0,0 +1,75 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing/fstest"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleHTTPOptions() {
+
+	svr := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			fmt.Fprintf(w, "%v %v %v\n", r.Method, r.Header.Get(`Authorization`), r.UserAgent())
+			fmt.Fprintf(w, "%s\n", body)
+		}))
+	defer svr.Close()
+
+	g := &get.Getter{
+		ConfDir: `/conf`,
+		FS: fstest.MapFS{
+			`conf/app/bootstrap`: {Data: []byte("bootstrap-token\n")},
+		},
+		HTTP: get.HTTPOptions{
+			Method: `POST`,
+			Header: http.Header{`User-Agent`: {`myapp/1.0`}},
+			Body:   `grant_type=client_credentials`,
+			Bearer: `conf:app/bootstrap`,
+		},
+	}
+
+	byt, err := g.HTTPContext(context.Background(), svr.URL+`/token`)
+	fmt.Printf("%s%v\n", byt, err)
+
+	fmt.Println(g.String(`https.head:` + svr.URL))
+
+	// Output:
+	// POST Bearer bootstrap-token myapp/1.0
+	// grant_type=client_credentials
+	// <nil>
+	// POST Bearer bootstrap-token myapp/1.0 <nil>
+}
+
+func ExampleHTTPOptions_basic() {
+
+	svr := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			user, pass, _ := r.BasicAuth()
+			fmt.Fprintf(w, "%v %v", user, pass)
+		}))
+	defer svr.Close()
+
+	g := &get.Getter{
+		LookupEnv: func(key string) (string, bool) {
+			return map[string]string{`API_USER`: `me`, `API_PASS`: `s3cr3t`}[key], true
+		},
+		HTTP: get.HTTPOptions{
+			Username: `env:API_USER`,
+			Password: `env:API_PASS`,
+		},
+	}
+
+	fmt.Println(g.String(svr.URL))
+
+	// Output:
+	// me s3cr3t <nil>
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// selectINI returns the value of the section.key selector from the INI
+// data. The selector is split at the last dot so that section names
+// may contain dots (ex: profile dev.region, remote "origin".url).
+// A selector without a dot is a key before the first section. Sections
+// and keys are matched exactly or, failing that, ignoring case.
+func selectINI(in []byte, selector string) ([]byte, error) {
+	section, key := ``, selector
+	if i := strings.LastIndex(selector, `.`); i >= 0 {
+		section, key = selector[:i], selector[i+1:]
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf(`%w: missing #section.key`, ErrInvalidURI)
+	}
+	sections, err := parseINI(string(in))
+	if err != nil {
+		return nil, err
+	}
+	keys, found := sections[section]
+	if !found {
+		for name, v := range sections {
+			if strings.EqualFold(name, section) {
+				keys, found = v, true
+				break
+			}
+		}
+	}
+	val, found := keys[key]
+	if !found {
+		for name, v := range keys {
+			if strings.EqualFold(name, key) {
+				val, found = v, true
+				break
+			}
+		}
+	}
+	if !found {
+		return nil, ErrNotFound
+	}
+	return []byte(val), nil
+}
+
+// parseINI returns the keys of every [section] (the blank section for
+// any before the first) from the INI data:
+//
+//   - lines beginning with ; or # are comments
+//   - keys and values are separated by = or :
+//   - values end at any ; or # after a space
+//   - values in double quotes are unquoted (and never end at a comment)
+//   - lines indented more than the key continue its value (joined with
+//     a line feed)
+//   - a line ending with a backslash continues on the next line
+//   - a later key replaces an earlier one of the same name
+func parseINI(text string) (map[string]map[string]string, error) {
+	sections := map[string]map[string]string{``: {}}
+	section, key, indent := ``, ``, 0
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for n := 0; n < len(lines); n++ {
+		raw := lines[n]
+		line := strings.TrimSpace(raw)
+		if len(line) == 0 || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		// continuation of a multi-line value indented more than its key
+		ind := len(raw) - len(strings.TrimLeft(raw, " \t"))
+		if len(key) > 0 && ind > indent {
+			keys := sections[section]
+			if len(keys[key]) > 0 {
+				keys[key] += "\n"
+			}
+			keys[key] += iniValue(line)
+			continue
+		}
+
+		if line[0] == '[' {
+			end := strings.IndexByte(line, ']')
+			if end < 0 {
+				return nil, fmt.Errorf(`ini: line %v: missing ]`, n+1)
+			}
+			section, key = strings.TrimSpace(line[1:end]), ``
+			if _, has := sections[section]; !has {
+				sections[section] = map[string]string{}
+			}
+			continue
+		}
+
+		i := strings.IndexAny(line, `=:`)
+		if i <= 0 {
+			return nil, fmt.Errorf(`ini: line %v: expected key = value`, n+1)
+		}
+		key, indent = strings.TrimSpace(line[:i]), ind
+		val := strings.TrimSpace(line[i+1:])
+		for strings.HasSuffix(val, `\`) && n+1 < len(lines) {
+			n++
+			val = val[:len(val)-1] + strings.TrimSpace(lines[n])
+		}
+		sections[section][key] = iniValue(val)
+	}
+	return sections, nil
+}
+
+// iniValue removes any trailing comment and surrounding double quotes.
+func iniValue(val string) string {
+	if strings.HasPrefix(val, `"`) {
+		if unq, err := strconv.Unquote(val); err == nil {
+			return unq
+		}
+		if end := strings.LastIndexByte(val, '"'); end > 0 {
+			return val[1:end]
+		}
+	}
+	for _, c := range []string{` ;`, ` #`, "\t;", "\t#"} {
+		if i := strings.Index(val, c); i >= 0 {
+			val = val[:i]
+		}
+	}
+	return strings.TrimSpace(val)
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"errors"
+	"fmt"
+	"testing/fstest"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleString_ini() {
+
+	g := &get.Getter{
+		HomeDir: `/home/me`,
+		FS: fstest.MapFS{
+			`home/me/.aws/credentials`: {Data: []byte(`; aws credentials
+[default]
+aws_access_key_id = AKIAEXAMPLE
+aws_secret_access_key = wJalrXUtnFEMI/K7MDENG ; rotated monthly
+
+[profile dev.us]
+region: us-west-2
+`)},
+			`home/me/.gitconfig`: {Data: []byte(`[remote "origin"]
+	url = "git@github.com:rwxrob/get.git"
+	fetch = +refs/heads/*:\
+		refs/remotes/origin/*
+`)},
+			`home/me/.pypirc`: {Data: []byte(`[distutils]
+index-servers =
+    pypi
+    testpypi
+`)},
+		},
+	}
+
+	for _, target := range []string{
+		`home.ini:.aws/credentials#default.aws_secret_access_key`,
+		`home.ini:.aws/credentials#profile dev.us.region`,
+		`home.ini:.gitconfig#remote "origin".url`,
+		`home.ini:.gitconfig#remote "origin".fetch`,
+		`home.ini:.pypirc#distutils.index-servers`,
+	} {
+		it, err := g.String(target)
+		fmt.Printf("%q %v\n", it, err)
+	}
+
+	// Output:
+	// "wJalrXUtnFEMI/K7MDENG" <nil>
+	// "us-west-2" <nil>
+	// "git@github.com:rwxrob/get.git" <nil>
+	// "+refs/heads/*:refs/remotes/origin/*" <nil>
+	// "pypi\ntestpypi" <nil>
+}
+
+func ExampleString_ini_missing() {
+
+	g := &get.Getter{
+		FS: fstest.MapFS{`credentials`: {Data: []byte("[default]\nkey = 1\n")}},
+	}
+
+	_, err := g.String(`file.ini:credentials#other.key`)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, get.ErrNotFound))
+
+	// Output:
+	// get: file.ini: ini other.key: not found
+	// true
+}
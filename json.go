@@ -0,0 +1,119 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// selectJSON returns the value at the JSONPath-like selector within the
+// JSON data. The selector is a dotted list of object keys with array
+// indexes in brackets (ex: data.items[0].token). Keys containing dots
+// or brackets may be quoted in brackets (ex: auths["ghcr.io"].auth).
+// A leading $ or dot is optional. Strings are returned unquoted, other
+// scalars as written (null as empty), and objects and arrays as compact
+// JSON. An empty selector returns the entire document.
+func selectJSON(in []byte, selector string) ([]byte, error) {
+	path, err := parseJSONPath(selector)
+	if err != nil {
+		return nil, err
+	}
+	raw := json.RawMessage(bytes.TrimSpace(in))
+	if !json.Valid(raw) {
+		return nil, fmt.Errorf(`invalid JSON`)
+	}
+	for _, key := range path {
+		var next json.RawMessage
+		var found bool
+		switch k := key.(type) {
+		case string:
+			var obj map[string]json.RawMessage
+			if json.Unmarshal(raw, &obj) == nil {
+				next, found = obj[k]
+			}
+		case int:
+			var arr []json.RawMessage
+			if json.Unmarshal(raw, &arr) == nil && k < len(arr) {
+				next, found = arr[k], true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf(`%w: %v`, ErrNotFound, key)
+		}
+		raw = next
+	}
+	return renderJSON(raw)
+}
+
+// renderJSON returns strings unquoted, null as empty, objects and
+// arrays compacted, and everything else as is.
+func renderJSON(raw json.RawMessage) ([]byte, error) {
+	switch raw[0] {
+	case '"':
+		var str string
+		if err := json.Unmarshal(raw, &str); err != nil {
+			return nil, err
+		}
+		return []byte(str), nil
+	case '{', '[':
+		buf := new(bytes.Buffer)
+		if err := json.Compact(buf, raw); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case 'n':
+		return []byte{}, nil
+	}
+	return raw, nil
+}
+
+// parseJSONPath returns the keys (string) and indexes (int) of the
+// selector.
+func parseJSONPath(selector string) ([]any, error) {
+	var path []any
+	s := strings.TrimPrefix(selector, `$`)
+	for len(s) > 0 {
+		switch s[0] {
+
+		case '.':
+			s = s[1:]
+
+		case '[':
+			if len(s) > 1 && (s[1] == '"' || s[1] == '\'') {
+				// quoted keys may contain ] so look for the quote first
+				end := strings.Index(s[2:], string(s[1])+`]`)
+				if end < 0 {
+					return nil, fmt.Errorf(`%w: unclosed [ in %q`, ErrInvalidURI, selector)
+				}
+				path = append(path, s[2:end+2])
+				s = s[end+4:]
+				continue
+			}
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, fmt.Errorf(`%w: unclosed [ in %q`, ErrInvalidURI, selector)
+			}
+			in := s[1:end]
+			i, err := strconv.Atoi(in)
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf(`%w: invalid index %q in %q`, ErrInvalidURI, in, selector)
+			}
+			path = append(path, i)
+			s = s[end+1:]
+
+		default:
+			end := strings.IndexAny(s, `.[`)
+			if end < 0 {
+				end = len(s)
+			}
+			path = append(path, s[:end])
+			s = s[end:]
+		}
+	}
+	return path, nil
+}
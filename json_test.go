@@ -0,0 +1,83 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing/fstest"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleString_json() {
+
+	g := &get.Getter{
+		ConfDir: `/conf`,
+		FS: fstest.MapFS{
+			`conf/gcloud/creds.json`: {Data: []byte(`{
+				"client_id": "someid",
+				"client_secret": "shhh",
+				"port": 8080,
+				"scopes": ["read", "write"],
+				"auths": {"ghcr.io": {"auth": "dXNlcg=="}},
+				"a]b": "bracketed"
+			}`)},
+		},
+	}
+
+	for _, target := range []string{
+		`conf.json:gcloud/creds.json#client_secret`,
+		`conf.json:gcloud/creds.json#port`,
+		`conf.json:gcloud/creds.json#scopes[1]`,
+		`conf.json:gcloud/creds.json#scopes`,
+		`conf.json:gcloud/creds.json#auths["ghcr.io"].auth`,
+		`conf.json:gcloud/creds.json#["a]b"]`,
+		`conf.json:gcloud/creds.json#['a]b']`,
+	} {
+		fmt.Println(g.String(target))
+	}
+
+	// Output:
+	// shhh <nil>
+	// 8080 <nil>
+	// write <nil>
+	// ["read","write"] <nil>
+	// dXNlcg== <nil>
+	// bracketed <nil>
+	// bracketed <nil>
+}
+
+func ExampleString_json_http() {
+
+	svr := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data":{"items":[{"token":"abc123"}]}}`)
+		}))
+	defer svr.Close()
+
+	fmt.Println(get.String(`http.json:` + svr.URL + `/meta#data.items[0].token`))
+	fmt.Println(get.String(`http.json:` + svr.URL + `/meta#data.items[0]`))
+
+	// Output:
+	// abc123 <nil>
+	// {"token":"abc123"} <nil>
+}
+
+func ExampleString_json_missing() {
+
+	g := &get.Getter{
+		FS: fstest.MapFS{`creds.json`: {Data: []byte(`{"user":"me"}`)}},
+	}
+
+	_, err := g.String(`file.json:creds.json#password`)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, get.ErrNotFound))
+
+	// Output:
+	// get: file.json: json password: not found: password
+	// true
+}
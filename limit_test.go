@@ -0,0 +1,88 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing/fstest"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleTooLargeError() {
+
+	svr := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == `/stream` {
+				w.(http.Flusher).Flush() // no Content-Length
+			}
+			fmt.Fprint(w, "first line\n"+strings.Repeat(`x`, 10000))
+		}))
+	defer svr.Close()
+
+	g := &get.Getter{
+		MaxSize: 5000,
+		FS: fstest.MapFS{
+			`small`: {Data: []byte(`small enough`)},
+			`big`:   {Data: []byte(strings.Repeat(`x`, 10000))},
+			`log`:   {Data: []byte(strings.Repeat("line\n", 2000) + "last\n")},
+		},
+	}
+
+	fmt.Println(g.String(`file:small`))
+
+	_, err := g.String(`file:big`)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, get.ErrTooLarge))
+
+	// line selection from local files keeps only the lines selected
+	fmt.Println(g.String(`file.tail:log`))
+	fmt.Println(g.String(`tail.2:log`))
+
+	// endless remote stream
+	g.SSHPath = `testdata/fakessh`
+	_, err = g.String(`ssh://localhost//dev/zero`)
+	fmt.Println(errors.Is(err, get.ErrTooLarge))
+
+	// remote line selection with a huge first line
+	tmp, _ := os.MkdirTemp(``, `limit`)
+	defer os.RemoveAll(tmp)
+	os.WriteFile(filepath.Join(tmp, `huge`), []byte(strings.Repeat(`x`, 10000)+"\n"), 0600)
+	_, err = g.String(`ssh.line.1://localhost/` + filepath.Join(tmp, `huge`))
+	fmt.Println(err)
+
+	// known from Content-Length
+	_, err = g.String(svr.URL)
+	fmt.Println(errors.Is(err, get.ErrTooLarge))
+
+	// only known after reading too much
+	_, err = g.String(svr.URL + `/stream`)
+	fmt.Println(errors.Is(err, get.ErrTooLarge))
+
+	var tl *get.TooLargeError
+	fmt.Println(errors.As(err, &tl), tl.Limit)
+
+	// stops reading soon after the first line
+	fmt.Println(g.String(`http.head:` + svr.URL + `/stream`))
+
+	// Output:
+	// small enough <nil>
+	// get: file: read big: too large: 10000 bytes exceeds limit of 5000
+	// true
+	// last <nil>
+	// line
+	// last <nil>
+	// true
+	// get: ssh.line.1: ssh localhost: too large: exceeds limit of 5000 bytes
+	// true
+	// true
+	// true 5000
+	// first line <nil>
+}
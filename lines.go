@@ -0,0 +1,127 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Lines is a selection of lines from Start to End (inclusive). Positive
+// numbers count from the first line (1) and negative numbers from the
+// last line (-1). The modifiers are equivalent to the following:
+//
+//	head       - Lines{1, 1}
+//	head.N     - Lines{1, N}
+//	tail       - Lines{-1, -1}
+//	tail.N     - Lines{-N, -1}
+//	line.N     - Lines{N, N}
+//	lines.A-B  - Lines{A, B}
+type Lines struct {
+	Start int
+	End   int
+}
+
+// String returns the modifier form of the selection.
+func (l Lines) String() string {
+	switch {
+	case l.Start == 1 && l.End == 1:
+		return `head`
+	case l.Start == -1 && l.End == -1:
+		return `tail`
+	case l.Start == 1:
+		return fmt.Sprintf(`head.%v`, l.End)
+	case l.End == -1:
+		return fmt.Sprintf(`tail.%v`, -l.Start)
+	case l.Start == l.End:
+		return fmt.Sprintf(`line.%v`, l.Start)
+	}
+	return fmt.Sprintf(`lines.%v-%v`, l.Start, l.End)
+}
+
+// parseLines returns the selection for the modifier parts (ex: head, 3)
+// or nil if there are none. Returns false if not a valid selection.
+func parseLines(parts []string) (*Lines, bool) {
+	num := func(i int) (int, bool) {
+		if len(parts) <= i {
+			return 1, true
+		}
+		n, err := strconv.Atoi(parts[i])
+		return n, err == nil && n > 0
+	}
+	var sel Lines
+	var ok bool
+	switch {
+	case len(parts) == 0:
+		return nil, true
+	case len(parts) > 2:
+		return nil, false
+	case parts[0] == `head`:
+		sel.Start = 1
+		sel.End, ok = num(1)
+	case parts[0] == `tail`:
+		sel.End = -1
+		sel.Start, ok = num(1)
+		sel.Start = -sel.Start
+	case parts[0] == `line` && len(parts) == 2:
+		sel.Start, ok = num(1)
+		sel.End = sel.Start
+	case parts[0] == `lines` && len(parts) == 2:
+		a, b, found := strings.Cut(parts[1], `-`)
+		if !found {
+			return nil, false
+		}
+		var aok, bok bool
+		parts = []string{a, b}
+		sel.Start, aok = num(0)
+		sel.End, bok = num(1)
+		ok = aok && bok && sel.Start <= sel.End
+	}
+	if !ok {
+		return nil, false
+	}
+	return &sel, true
+}
+
+// selectLines reads only as much from r as needed to return the
+// selected lines joined with a line feed. Line endings (\r?\n) are
+// removed from every line. Negative selections keep only as many lines
+// in memory as are needed.
+func selectLines(ctx context.Context, r io.Reader, sel Lines) (string, error) {
+	s := bufio.NewScanner(r)
+	var lines []string
+
+	if sel.Start > 0 {
+		for n := 1; n <= sel.End && s.Scan(); n++ {
+			if err := ctx.Err(); err != nil {
+				return ``, err
+			}
+			if n >= sel.Start {
+				lines = append(lines, s.Text())
+			}
+		}
+		return strings.Join(lines, "\n"), s.Err()
+	}
+
+	for s.Scan() {
+		if err := ctx.Err(); err != nil {
+			return ``, err
+		}
+		lines = append(lines, s.Text())
+		if len(lines) > -sel.Start {
+			lines = lines[1:]
+		}
+	}
+	if end := len(lines) + sel.End + 1; end < len(lines) {
+		if end < 0 {
+			end = 0
+		}
+		lines = lines[:end]
+	}
+	return strings.Join(lines, "\n"), s.Err()
+}
@@ -0,0 +1,120 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleLines() {
+
+	// testdata/numbers contains one through six, one per line
+
+	for _, target := range []string{
+		`file.line.2:testdata/numbers`,
+		`file.lines.2-4:testdata/numbers`,
+		`file.head.2:testdata/numbers`,
+		`file.tail.2:testdata/numbers`,
+		`tail.3:testdata/numbers`,
+		`file.line.10:testdata/numbers`,
+	} {
+		it, err := get.String(target)
+		fmt.Printf("%q %v\n", it, err)
+	}
+
+	// Output:
+	// "two" <nil>
+	// "two\nthree\nfour" <nil>
+	// "one\ntwo" <nil>
+	// "five\nsix" <nil>
+	// "four\nfive\nsix" <nil>
+	// "" <nil>
+}
+
+func ExampleLines_String() {
+	fmt.Println(get.Lines{Start: 1, End: 1})
+	fmt.Println(get.Lines{Start: 1, End: 3})
+	fmt.Println(get.Lines{Start: -1, End: -1})
+	fmt.Println(get.Lines{Start: -10, End: -1})
+	fmt.Println(get.Lines{Start: 2, End: 2})
+	fmt.Println(get.Lines{Start: 2, End: 5})
+	// Output:
+	// head
+	// head.3
+	// tail
+	// tail.10
+	// line.2
+	// lines.2-5
+}
+
+func ExampleLines_env() {
+	os.Setenv(`CREDS`, "user\npassword\n")
+	defer os.Unsetenv(`CREDS`)
+
+	fmt.Println(get.String(`env.line.2:CREDS`))
+
+	// Output:
+	// password <nil>
+}
+
+func ExampleLines_ssh() {
+
+	g := &get.Getter{SSHPath: `testdata/fakessh`}
+
+	for _, target := range []string{
+		`ssh.line.2://localhost/testdata/numbers`,
+		`ssh.lines.3-4://localhost/testdata/numbers`,
+		`ssh.head.2://localhost/testdata/numbers`,
+		`ssh.tail.2://localhost/testdata/numbers`,
+	} {
+		it, err := g.String(target)
+		fmt.Printf("%q %v\n", it, err)
+	}
+
+	// Output:
+	// "two" <nil>
+	// "three\nfour" <nil>
+	// "one\ntwo" <nil>
+	// "five\nsix" <nil>
+}
+
+func ExampleLines_http() {
+
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "first line\nsecond line\nlast line\n")
+		})
+	svr := httptest.NewServer(handler)
+	defer svr.Close()
+
+	fmt.Println(get.String(`http.line.2:` + svr.URL[5:]))
+
+	// Output:
+	// second line <nil>
+}
+
+func ExampleSchema_lines() {
+
+	for _, it := range []string{
+		`file.line.2:x`, `env.file.lines.2-5:x`, `head.3:x`,
+		`file.line:x`, `file.lines.5-2:x`, `file.head.0:x`, `file.tail.x:x`,
+	} {
+		schema, value := get.Schema(it)
+		fmt.Printf("schema: %q value: %q\n", schema, value)
+	}
+
+	// Output:
+	// schema: "file.line.2" value: "x"
+	// schema: "env.file.lines.2-5" value: "x"
+	// schema: "head.3" value: "x"
+	// schema: "" value: "file.line:x"
+	// schema: "" value: "file.lines.5-2:x"
+	// schema: "" value: "file.head.0:x"
+	// schema: "" value: "file.tail.x:x"
+}
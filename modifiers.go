@@ -0,0 +1,196 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// modifier is a single parsed modifier from a schema (ex: line.2, json)
+// that is applied to the data after it has been fetched.
+type modifier struct {
+	name     string // as written (ex: line.2)
+	lines    *Lines // only for line selection
+	selector bool   // uses the #selector from the value
+	apply    func(ctx context.Context, g *Getter, in []byte, selector string) ([]byte, error)
+}
+
+// modifiers contains the parsers for each modifier by name. The parser
+// is passed any dotted arguments that follow the name (ex: 2 for
+// line.2) and returns false if they are invalid. Modifier names are
+// reserved and cannot be used in a schema name.
+var modifiers = map[string]func(args []string) (modifier, bool){}
+
+func init() {
+	for _, name := range []string{`head`, `tail`, `line`, `lines`} {
+		modifiers[name] = linesModifier(name)
+	}
+	modifiers[`json`] = selectorModifier(`json`, selectJSON)
+	modifiers[`yaml`] = selectorModifier(`yaml`, selectYAML)
+	modifiers[`toml`] = selectorModifier(`toml`, selectTOML)
+	modifiers[`dotenv`] = dotenvModifier
+	modifiers[`ini`] = selectorModifier(`ini`, selectINI)
+	modifiers[`re`] = reModifier
+	modifiers[`b64`] = transformModifier(`b64`,
+		decodeBase64(base64.StdEncoding, base64.RawStdEncoding))
+	modifiers[`b64url`] = transformModifier(`b64url`,
+		decodeBase64(base64.URLEncoding, base64.RawURLEncoding))
+	modifiers[`hex`] = transformModifier(`hex`, decodeHex)
+	modifiers[`gunzip`] = gunzipModifier
+	modifiers[`trim`] = transformModifier(`trim`, trim)
+}
+
+// parseModifiers parses the dotted parts of a schema that follow the
+// registered name into modifiers. Any part that is not the name of
+// a modifier is an argument to the modifier before it.
+func parseModifiers(parts []string) ([]modifier, error) {
+	var mods []modifier
+	for len(parts) > 0 {
+		parse, is := modifiers[parts[0]]
+		if !is {
+			return nil, fmt.Errorf(`unknown modifier %q`, parts[0])
+		}
+		n := 1
+		for n < len(parts) {
+			if _, is := modifiers[parts[n]]; is {
+				break
+			}
+			n++
+		}
+		mod, ok := parse(parts[1:n])
+		if !ok {
+			return nil, fmt.Errorf(`invalid modifier %q`, strings.Join(parts[:n], `.`))
+		}
+		mods = append(mods, mod)
+		parts = parts[n:]
+	}
+	return mods, nil
+}
+
+// usesSelector returns true if any of the modifiers use a selector.
+func usesSelector(mods []modifier) bool {
+	for _, m := range mods {
+		if m.selector {
+			return true
+		}
+	}
+	return false
+}
+
+// splitSelector separates the #selector from the value if any of the
+// modifiers use one. Otherwise, the value is returned unchanged.
+func splitSelector(value string, mods []modifier) (string, string) {
+	if !usesSelector(mods) {
+		return value, ``
+	}
+	value, selector, _ := strings.Cut(value, `#`)
+	return value, selector
+}
+
+func linesModifier(name string) func([]string) (modifier, bool) {
+	return func(args []string) (modifier, bool) {
+		sel, ok := parseLines(append([]string{name}, args...))
+		if !ok {
+			return modifier{}, false
+		}
+		return modifier{
+			name:  sel.String(),
+			lines: sel,
+			apply: func(ctx context.Context, _ *Getter, in []byte, _ string) ([]byte, error) {
+				out, err := selectLines(ctx, strings.NewReader(string(in)), *sel)
+				return []byte(out), err
+			},
+		}, true
+	}
+}
+
+// selectorModifier returns a parser for a modifier that takes no
+// arguments and uses the #selector.
+func selectorModifier(name string,
+	sel func(in []byte, selector string) ([]byte, error)) func([]string) (modifier, bool) {
+	return func(args []string) (modifier, bool) {
+		if len(args) > 0 {
+			return modifier{}, false
+		}
+		return modifier{
+			name:     name,
+			selector: true,
+			apply: func(_ context.Context, _ *Getter, in []byte, selector string) ([]byte, error) {
+				out, err := sel(in, selector)
+				return out, fetchErr(name, selector, err)
+			},
+		}, true
+	}
+}
+
+// transformModifier returns a parser for a modifier that takes no
+// arguments and transforms all of the data (ex: b64, trim).
+func transformModifier(name string,
+	transform func(in []byte) ([]byte, error)) func([]string) (modifier, bool) {
+	return func(args []string) (modifier, bool) {
+		if len(args) > 0 {
+			return modifier{}, false
+		}
+		return modifier{
+			name: name,
+			apply: func(_ context.Context, _ *Getter, in []byte, _ string) ([]byte, error) {
+				out, err := transform(in)
+				return out, fetchErr(name, ``, err)
+			},
+		}, true
+	}
+}
+
+// selectKey returns the scalar at the dotted key path within the parsed
+// data (maps, lists, and strings). Since keys may themselves contain
+// dots (ex: github.com) the longest matching key is tried first at each
+// level. Numbers index into lists. Returns ErrNotFound if there is no
+// such key and ErrNotScalar if the value is a map or list.
+func selectKey(data any, selector string) ([]byte, error) {
+	var parts []string
+	if len(selector) > 0 {
+		parts = strings.Split(selector, `.`)
+	}
+	v, found := walkKey(data, parts)
+	if !found {
+		return nil, ErrNotFound
+	}
+	switch v := v.(type) {
+	case nil:
+		return []byte{}, nil
+	case string:
+		return []byte(v), nil
+	case []any:
+		return nil, fmt.Errorf(`%w: list`, ErrNotScalar)
+	}
+	return nil, fmt.Errorf(`%w: map`, ErrNotScalar)
+}
+
+func walkKey(data any, parts []string) (any, bool) {
+	if len(parts) == 0 {
+		return data, true
+	}
+	switch d := data.(type) {
+	case map[string]any:
+		for n := len(parts); n > 0; n-- {
+			v, has := d[strings.Join(parts[:n], `.`)]
+			if !has {
+				continue
+			}
+			if found, ok := walkKey(v, parts[n:]); ok {
+				return found, true
+			}
+		}
+	case []any:
+		i, err := strconv.Atoi(parts[0])
+		if err == nil && i >= 0 && i < len(d) {
+			return walkKey(d[i], parts[1:])
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,187 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// netrcEntry is a single machine (or default) entry from a netrc file.
+type netrcEntry struct {
+	machine  string // blank for default
+	login    string
+	password string
+	account  string
+}
+
+// netrc returns the login, password, or account (after the #) for the
+// machine in the value (ex: api.example.com#login). The password is
+// returned when no field is given.
+func (g *Getter) netrc(ctx context.Context, value string) (string, error) {
+	host, field, _ := strings.Cut(value, `#`)
+	if len(host) == 0 {
+		return ``, fetchErr(`netrc`, value, fmt.Errorf(`%w: missing machine`, ErrInvalidURI))
+	}
+	e, err := g.netrcEntry(ctx, host)
+	if err != nil {
+		return ``, err
+	}
+	if e == nil {
+		return ``, fetchErr(`netrc`, host, ErrNotFound)
+	}
+	var val string
+	switch field {
+	case ``, `password`:
+		val = e.password
+	case `login`:
+		val = e.login
+	case `account`:
+		val = e.account
+	default:
+		return ``, fetchErr(`netrc`, host,
+			fmt.Errorf(`%w: unknown field %q (login, password, account)`, ErrInvalidURI, field))
+	}
+	if len(val) == 0 {
+		return ``, fetchErr(`netrc`, host+`#`+field, ErrNotFound)
+	}
+	return val, nil
+}
+
+// netrcEntry returns the entry for the host from the netrc file (see
+// netrcPath) or the default entry if there is no match. Returns nil if
+// neither are found.
+func (g *Getter) netrcEntry(ctx context.Context, host string) (*netrcEntry, error) {
+	name, err := g.netrcPath()
+	if err != nil {
+		return nil, err
+	}
+	byt, err := g.readFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := parseNetrc(string(byt))
+	if err != nil {
+		return nil, fetchErr(`netrc`, name, err)
+	}
+	var def *netrcEntry
+	for i, e := range entries {
+		switch {
+		case len(e.machine) == 0:
+			def = &entries[i]
+		case strings.EqualFold(e.machine, host):
+			return &entries[i], nil
+		}
+	}
+	return def, nil
+}
+
+// netrcPath returns the value of NETRC (if set) or .netrc in the home
+// directory.
+func (g *Getter) netrcPath() (string, error) {
+	if name, _ := g.lookupEnv(`NETRC`); len(name) > 0 {
+		return name, nil
+	}
+	home, err := g.homeDir()
+	if err != nil {
+		return ``, err
+	}
+	return path.Join(home, `.netrc`), nil
+}
+
+// parseNetrc returns the machine and default entries from the netrc
+// data. Tokens are separated by any white space and may be double
+// quoted. Lines beginning with # and macdef definitions (which end at
+// the next blank line) are skipped.
+func parseNetrc(text string) ([]netrcEntry, error) {
+	var entries []netrcEntry
+	var cur *netrcEntry
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var tokens []string
+	for n := 0; n < len(lines); n++ {
+		line := strings.TrimSpace(lines[n])
+		if strings.HasPrefix(line, `#`) {
+			continue
+		}
+		toks, err := netrcTokens(line)
+		if err != nil {
+			return nil, fmt.Errorf(`line %v: %w`, n+1, err)
+		}
+		for i := 0; i < len(toks); i++ {
+			tok := toks[i]
+			if tok == `macdef` {
+				for n++; n < len(lines) && len(strings.TrimSpace(lines[n])) > 0; n++ {
+				}
+				break
+			}
+			tokens = append(tokens, tok)
+		}
+	}
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok == `default` {
+			entries = append(entries, netrcEntry{})
+			cur = &entries[len(entries)-1]
+			continue
+		}
+		if i+1 >= len(tokens) {
+			return nil, fmt.Errorf(`missing value for %v`, tok)
+		}
+		i++
+		val := tokens[i]
+		if tok == `machine` {
+			entries = append(entries, netrcEntry{machine: val})
+			cur = &entries[len(entries)-1]
+			continue
+		}
+		if cur == nil {
+			return nil, fmt.Errorf(`%v before machine or default`, tok)
+		}
+		switch tok {
+		case `login`:
+			cur.login = val
+		case `password`:
+			cur.password = val
+		case `account`:
+			cur.account = val
+		}
+	}
+	return entries, nil
+}
+
+// netrcTokens splits the line at white space keeping double-quoted
+// tokens (with \ escapes) together.
+func netrcTokens(line string) ([]string, error) {
+	var tokens []string
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if len(line) == 0 {
+			return tokens, nil
+		}
+		if line[0] != '"' {
+			end := strings.IndexAny(line, " \t")
+			if end < 0 {
+				end = len(line)
+			}
+			tokens = append(tokens, line[:end])
+			line = line[end:]
+			continue
+		}
+		var buf strings.Builder
+		i := 1
+		for ; i < len(line) && line[i] != '"'; i++ {
+			if line[i] == '\\' && i+1 < len(line) {
+				i++
+			}
+			buf.WriteByte(line[i])
+		}
+		if i >= len(line) {
+			return nil, fmt.Errorf(`unterminated quote`)
+		}
+		tokens = append(tokens, buf.String())
+		line = line[i+1:]
+	}
+}
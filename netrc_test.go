@@ -0,0 +1,94 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing/fstest"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleString_netrc() {
+
+	g := &get.Getter{
+		HomeDir: `/home/me`,
+		FS: fstest.MapFS{
+			`home/me/.netrc`: {Data: []byte(`# work
+machine api.example.com login me password "s3cr3t pass" account dev
+macdef init
+cd /pub
+
+default login anonymous password guest
+`)},
+		},
+	}
+
+	for _, target := range []string{
+		`netrc:api.example.com`,
+		`netrc:api.example.com#login`,
+		`netrc:api.example.com#account`,
+		`netrc:other.example.com#login`,
+	} {
+		fmt.Println(g.String(target))
+	}
+
+	// Output:
+	// s3cr3t pass <nil>
+	// me <nil>
+	// dev <nil>
+	// anonymous <nil>
+}
+
+func ExampleString_netrc_env() {
+
+	g := &get.Getter{
+		LookupEnv: func(key string) (string, bool) {
+			if key == `NETRC` {
+				return `/etc/netrc`, true
+			}
+			return ``, false
+		},
+		FS: fstest.MapFS{
+			`etc/netrc`: {Data: []byte("machine host login me password pw\n")},
+		},
+	}
+
+	fmt.Println(g.String(`netrc:host`))
+	_, err := g.String(`netrc:other`)
+	fmt.Println(errors.Is(err, get.ErrNotFound))
+
+	// Output:
+	// pw <nil>
+	// true
+}
+
+func ExampleGetter_netrc() {
+
+	svr := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			user, pass, ok := r.BasicAuth()
+			fmt.Fprintf(w, `%v %v %v`, user, pass, ok)
+		}))
+	defer svr.Close()
+
+	g := &get.Getter{
+		Netrc:   true,
+		HomeDir: `/home/me`,
+		FS: fstest.MapFS{
+			`home/me/.netrc`: {Data: []byte("machine 127.0.0.1 login me password pw\n")},
+		},
+	}
+
+	fmt.Println(g.String(svr.URL))
+	g.Netrc = false
+	fmt.Println(g.String(svr.URL))
+
+	// Output:
+	// me pw true <nil>
+	//   false <nil>
+}
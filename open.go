@@ -0,0 +1,197 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Open returns a stream of the data for the target (see String for
+// schemas) rather than buffering all of it into memory, which is
+// preferable for large payloads. Files are read directly, HTTP bodies
+// are streamed as they arrive, ssh uses a remote cat piped from the
+// ssh command, and scp copies into a temporary directory. The caller
+// must always Close the reader, which removes any temporary directory
+// and kills any child process that has not yet completed.
+func Open(target string) (io.ReadCloser, error) {
+	return Default.Open(target)
+}
+
+// OpenContext is the same as Open but with a context (see
+// StringContext).
+func OpenContext(ctx context.Context, target string) (io.ReadCloser, error) {
+	return Default.OpenContext(ctx, target)
+}
+
+// Open is the same as the package Open function but uses the
+// configuration of the Getter.
+func (g *Getter) Open(target string) (io.ReadCloser, error) {
+	return g.OpenContext(context.Background(), target)
+}
+
+// OpenContext is the same as the package OpenContext function but uses
+// the configuration of the Getter.
+func (g *Getter) OpenContext(ctx context.Context, target string) (io.ReadCloser, error) {
+	if chain := splitChain(target); chain != nil {
+		return g.openChain(ctx, chain)
+	}
+
+	t, f, mods, err := parseTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	// not a reserved schema, must just be a string
+	if f == nil {
+		return io.NopCloser(strings.NewReader(target)), nil
+	}
+
+	schema, _, _ := strings.Cut(target, `:`)
+	if g.ExpandValues {
+		if t.Value, err = g.expandValue(t.Source, t.Value); err != nil {
+			return nil, withSchema(schema, target, err)
+		}
+	}
+	rc, err := g.open(ctx, f, mods, t.Value, t.Selector)
+	if err != nil {
+		return nil, withSchema(schema, target, err)
+	}
+	return &errReader{rc, schema, target}, nil
+}
+
+// open opens the value with the Fetcher and applies any modifiers in
+// order. The first line selection (if first) is done by the Fetcher
+// itself when it is a LineFetcher.
+func (g *Getter) open(ctx context.Context, f Fetcher, mods []modifier, value, selector string) (io.ReadCloser, error) {
+	if len(mods) == 0 {
+		return f.Open(ctx, g, value)
+	}
+	var data []byte
+	if sel := mods[0].lines; sel != nil {
+		var lines string
+		var err error
+		if lf, is := f.(LineFetcher); is {
+			lines, err = lf.Lines(ctx, g, value, *sel)
+		} else {
+			lines, err = g.readLines(ctx, f, value, *sel)
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, mods = []byte(lines), mods[1:]
+	} else {
+		rc, err := f.Open(ctx, g, value)
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		if data, err = io.ReadAll(rc); err != nil {
+			return nil, err
+		}
+	}
+	for _, m := range mods {
+		var err error
+		if data, err = m.apply(ctx, g, data, selector); err != nil {
+			return nil, err
+		}
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// readLines opens the value with the Fetcher and returns the selected
+// lines.
+func (g *Getter) readLines(ctx context.Context, f Fetcher, value string, sel Lines) (string, error) {
+	rc, err := f.Open(ctx, g, value)
+	if err != nil {
+		return ``, err
+	}
+	defer rc.Close()
+	return selectLines(ctx, rc, sel)
+}
+
+// errReader adds the schema and target to any read error.
+type errReader struct {
+	io.ReadCloser
+	schema, target string
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		err = withSchema(r.schema, r.target, err)
+	}
+	return n, err
+}
+
+// cmdReader streams the standard output of a started command. The
+// error (if any) from the command is returned in place of io.EOF.
+// Closing before the end kills the command.
+type cmdReader struct {
+	io.ReadCloser
+	cmd    *exec.Cmd
+	stderr *bytes.Buffer
+	op     string
+	target string
+	done   bool
+	err    error
+}
+
+func (r *cmdReader) wait() error {
+	if !r.done {
+		r.done = true
+		r.err = fetchErr(r.op, r.target, withStderr(r.cmd.Wait(), r.stderr.Bytes()))
+	}
+	return r.err
+}
+
+func (r *cmdReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err == io.EOF {
+		if werr := r.wait(); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+func (r *cmdReader) Close() error {
+	if !r.done {
+		r.cmd.Process.Kill()
+		r.wait()
+	}
+	return nil
+}
+
+// startCmd starts the command and returns a cmdReader of its output.
+func startCmd(cmd *exec.Cmd, op, target string) (io.ReadCloser, error) {
+	r := &cmdReader{cmd: cmd, stderr: new(bytes.Buffer), op: op, target: target}
+	cmd.Stderr = r.stderr
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fetchErr(op, target, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fetchErr(op, target, err)
+	}
+	r.ReadCloser = out
+	return r, nil
+}
+
+// tempFile is an open file within a temporary directory that is
+// removed when the file is closed.
+type tempFile struct {
+	*os.File
+	dir string
+}
+
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	os.RemoveAll(f.dir)
+	return err
+}
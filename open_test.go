@@ -0,0 +1,125 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleOpen() {
+
+	r, err := get.Open(`file:testdata/datafile`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer r.Close()
+	io.Copy(os.Stdout, r)
+
+	// Output:
+	// first line
+	// second line
+	// last line
+}
+
+func ExampleOpen_http() {
+
+	handler := http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "first line\nsecond line\nlast line\n")
+		})
+	svr := httptest.NewServer(handler)
+	defer svr.Close()
+
+	r, err := get.Open(svr.URL)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer r.Close()
+	io.Copy(os.Stdout, r)
+
+	// Output:
+	// first line
+	// second line
+	// last line
+}
+
+func ExampleOpen_ssh() {
+
+	g := &get.Getter{SSHPath: `testdata/fakessh`}
+
+	// endless stream, remote cat is killed on Close
+	r, err := g.Open(`ssh://localhost//dev/zero`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	buf := make([]byte, 4)
+	n, err := io.ReadFull(r, buf)
+	fmt.Println(n, err, buf)
+	fmt.Println(r.Close())
+
+	// Output:
+	// 4 <nil> [0 0 0 0]
+	// <nil>
+}
+
+func ExampleString_ssh_quoted() {
+
+	g := &get.Getter{SSHPath: `testdata/fakessh`}
+
+	// the remote shell never interprets the path
+	for _, target := range []string{
+		`ssh://localhost/testdata/datafile;echo injected`,
+		`ssh.tail://localhost/testdata/$(echo datafile)`,
+		`ssh.line.2://localhost/testdata/it's`,
+	} {
+		out, err := g.String(target)
+		fmt.Printf("%q %v\n", out, err != nil)
+	}
+
+	// Output:
+	// "" true
+	// "" true
+	// "" true
+}
+
+func ExampleOpen_scp() {
+
+	tmp, _ := os.MkdirTemp(``, `example`)
+	defer os.RemoveAll(tmp)
+	orig := os.Getenv(`TMPDIR`)
+	os.Setenv(`TMPDIR`, tmp)
+	defer os.Setenv(`TMPDIR`, orig)
+
+	g := &get.Getter{SCPPath: `testdata/fakescp`}
+
+	r, err := g.Open(`scp://localhost/testdata/datafile`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	io.Copy(os.Stdout, r)
+
+	// temporary directory is removed on Close
+	entries, _ := os.ReadDir(tmp)
+	fmt.Println(len(entries))
+	r.Close()
+	entries, _ = os.ReadDir(tmp)
+	fmt.Println(len(entries))
+
+	// Output:
+	// first line
+	// second line
+	// last line
+	// 1
+	// 0
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// reModifier returns the re modifier, which takes an optional group
+// name argument (ex: re.token).
+func reModifier(args []string) (modifier, bool) {
+	if len(args) > 1 {
+		return modifier{}, false
+	}
+	name := `re`
+	var group string
+	if len(args) == 1 {
+		group = args[0]
+		name += `.` + group
+	}
+	return modifier{
+		name:     name,
+		selector: true,
+		apply: func(_ context.Context, _ *Getter, in []byte, expr string) ([]byte, error) {
+			out, err := selectRegexp(in, expr, group)
+			return out, fetchErr(`re`, expr, err)
+		},
+	}, true
+}
+
+// selectRegexp returns the named group (if not blank) or first capture
+// group of the first match of the regular expression within the data.
+// The entire match is returned if the expression has no groups.
+// Returns ErrNotFound if nothing matches.
+func selectRegexp(in []byte, expr, group string) ([]byte, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf(`%w: %v`, ErrInvalidURI, err)
+	}
+	n := 0
+	if re.NumSubexp() > 0 {
+		n = 1
+	}
+	if len(group) > 0 {
+		if n = re.SubexpIndex(group); n < 0 {
+			return nil, fmt.Errorf(`%w: no group named %v`, ErrInvalidURI, group)
+		}
+	}
+	m := re.FindSubmatch(in)
+	if m == nil {
+		return nil, fmt.Errorf(`%w: no match`, ErrNotFound)
+	}
+	return m[n], nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"errors"
+	"fmt"
+	"testing/fstest"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleString_re() {
+
+	g := &get.Getter{
+		FS: fstest.MapFS{
+			`etc/app.sh`: {Data: []byte(`#!/bin/sh
+# app settings
+export USER=me
+export TOKEN=abc123 # rotated
+`)},
+		},
+	}
+
+	for _, target := range []string{
+		`file.re:/etc/app.sh#export TOKEN=(\S+)`,
+		`file.re.user:/etc/app.sh#USER=(?P<user>\w+)`,
+		`file.re:/etc/app.sh#(?m)^# .*$`,
+		`file.tail.re:/etc/app.sh#=(\w+)`,
+	} {
+		fmt.Println(g.String(target))
+	}
+
+	// Output:
+	// abc123 <nil>
+	// me <nil>
+	// # app settings <nil>
+	// abc123 <nil>
+}
+
+func ExampleString_re_no_match() {
+
+	g := &get.Getter{
+		FS: fstest.MapFS{`app.sh`: {Data: []byte("export USER=me\n")}},
+	}
+
+	_, err := g.String(`file.re:app.sh#TOKEN=(\S+)`)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, get.ErrNotFound))
+
+	// Output:
+	// get: file.re: re TOKEN=(\S+): not found: no match
+	// true
+}
@@ -0,0 +1,108 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Fetcher is implemented by anything that can open a stream of the
+// data for the value portion of a target (everything after the first
+// colon) for a given schema. The returned io.ReadCloser must release
+// all resources (temporary files, child processes, connections) when
+// closed. Implementations must honor the cancellation and deadline of
+// the context and should use the configuration of the Getter (which is
+// never nil) where it applies. See Register.
+type Fetcher interface {
+	Open(ctx context.Context, g *Getter, value string) (io.ReadCloser, error)
+}
+
+// FetcherFunc is an adapter allowing an ordinary function that returns
+// the full string data to be used as a Fetcher.
+type FetcherFunc func(ctx context.Context, g *Getter, value string) (string, error)
+
+// Open calls f(ctx, g, value) and returns a reader of the string.
+func (f FetcherFunc) Open(ctx context.Context, g *Getter, value string) (io.ReadCloser, error) {
+	str, err := f(ctx, g, value)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(strings.NewReader(str)), nil
+}
+
+// LineFetcher may optionally be implemented by a Fetcher that can
+// return selected lines more efficiently than opening all of its data
+// (running head, tail, or sed remotely, for example). Otherwise, the
+// lines are selected while streaming from Open, which stops reading as
+// soon as the last selected line has been read. The selected lines
+// must be joined with a single line feed and have no line ending after
+// the last line.
+type LineFetcher interface {
+	Lines(ctx context.Context, g *Getter, value string, sel Lines) (string, error)
+}
+
+var registry = struct {
+	sync.RWMutex
+	fetchers map[string]Fetcher
+}{fetchers: map[string]Fetcher{}}
+
+// Register makes a Fetcher available to Schema and String under the
+// given schema name. Registering a name that already exists replaces
+// the previous Fetcher (including the built-in ones). Every registered
+// name automatically also supports all of the modifiers (ex: vault,
+// vault.head, vault.tail.3, vault.line.2, vault.json). Register panics
+// if the name is empty, contains a colon or a modifier, or if fetcher
+// is nil. It is safe to call from multiple goroutines.
+func Register(name string, fetcher Fetcher) {
+	if fetcher == nil {
+		panic(`get: Register fetcher is nil`)
+	}
+	if len(name) == 0 || strings.Contains(name, `:`) {
+		panic(`get: invalid schema name ` + name)
+	}
+	for _, part := range strings.Split(name, `.`) {
+		if _, is := modifiers[part]; is {
+			panic(`get: schema name must not contain a modifier: ` + name)
+		}
+	}
+	register(name, fetcher)
+}
+
+// register adds the Fetcher without checking the name so that built-in
+// schemas may share a name with a modifier (dotenv).
+func register(name string, fetcher Fetcher) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.fetchers[name] = fetcher
+}
+
+// Unregister removes the Fetcher registered under name (if any).
+func Unregister(name string) {
+	registry.Lock()
+	defer registry.Unlock()
+	delete(registry.fetchers, name)
+}
+
+// Schemes returns a sorted list of the currently registered schema
+// names (not including the modifier variations).
+func Schemes() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.fetchers))
+	for name := range registry.fetchers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func registered(name string) Fetcher {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.fetchers[name]
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleRegister() {
+
+	get.Register(`upper`, get.FetcherFunc(
+		func(_ context.Context, _ *get.Getter, value string) (string, error) {
+			return strings.ToUpper(value), nil
+		}))
+	defer get.Unregister(`upper`)
+
+	it, err := get.String("upper:first line\nlast line")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(it)
+
+	// head and tail modifiers come for free
+	it, err = get.String("upper.tail:first line\nlast line")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(it)
+
+	// Output:
+	// FIRST LINE
+	// LAST LINE
+	// LAST LINE
+}
+
+func ExampleUnregister() {
+
+	get.Register(`foo`, get.FetcherFunc(
+		func(context.Context, *get.Getter, string) (string, error) { return `bar`, nil }))
+
+	fmt.Println(get.String(`foo:it`))
+	get.Unregister(`foo`)
+	fmt.Println(get.String(`foo:it`))
+
+	// Output:
+	// bar <nil>
+	// foo:it <nil>
+}
+
+func ExampleSchemes() {
+	fmt.Println(get.Schemes())
+	// Output:
+	// [cache conf dotenv embed env env.file file home http https netrc scp ssh]
+}
@@ -0,0 +1,216 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy determines if and when a failed remote fetch (http,
+// https, ssh, and scp) is tried again. The zero value never retries.
+// The delay before each retry starts at BaseDelay and doubles after
+// every attempt up to MaxDelay. A Retry-After header on the response
+// is used instead (up to MaxDelay). When all attempts fail, the
+// FetchError returned contains a RetryError with the cause of every
+// attempt.
+type RetryPolicy struct {
+
+	// MaxAttempts is the total number of attempts including the first.
+	// Zero or one means there are no retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry (100ms when zero).
+	BaseDelay time.Duration
+
+	// MaxDelay is the maximum delay between attempts (no maximum when
+	// zero).
+	MaxDelay time.Duration
+
+	// Jitter is the fraction (0.0 to 1.0) of each delay that is
+	// randomly removed so that many clients do not retry in lockstep.
+	Jitter float64
+
+	// Retryable returns true if the error is worth trying again. When
+	// nil, IsRetryable is used.
+	Retryable func(err error) bool
+}
+
+// IsRetryable returns true for errors that are usually transient: any
+// HTTPError with a 5xx or 429 (Too Many Requests) status, connection
+// resets, refusals, and timeouts, and ssh exiting with 255 (its code
+// for a connection error). Context errors are never retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var he *HTTPError
+	if errors.As(err, &he) {
+		return he.StatusCode >= 500 || he.StatusCode == http.StatusTooManyRequests
+	}
+	var ee *exec.ExitError
+	if errors.As(err, &ee) {
+		return ee.ExitCode() == 255
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// RetryError contains the cause of every failed attempt (in order) when
+// more than one was made (see RetryPolicy). Both errors.Is and
+// errors.As check every attempt.
+type RetryError struct {
+	Attempts []error
+}
+
+// Error fulfills the error interface listing every attempt.
+func (e *RetryError) Error() string {
+	msgs := make([]string, len(e.Attempts))
+	for i, err := range e.Attempts {
+		msgs[i] = err.Error()
+	}
+	return strconv.Itoa(len(e.Attempts)) + ` attempts: ` + strings.Join(msgs, `; `)
+}
+
+// Unwrap returns every attempt.
+func (e *RetryError) Unwrap() []error { return e.Attempts }
+
+// Is returns true if any of the attempts match the target (see
+// errors.Is).
+func (e *RetryError) Is(target error) bool {
+	for _, err := range e.Attempts {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the attempts that matches the target (see
+// errors.As). Unwrap alone is not enough before Go 1.20.
+func (e *RetryError) As(target any) bool {
+	for _, err := range e.Attempts {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// retry calls fn until it succeeds or the RetryPolicy of the Getter
+// says to stop. When more than one attempt fails the last error is
+// returned with a RetryError of the causes of all of them in place of
+// its own cause.
+func (g *Getter) retry(ctx context.Context, fn func() error) error {
+	p := g.Retry
+	var errs []error
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+		if attempt >= p.MaxAttempts || !p.retryable(err) || ctx.Err() != nil {
+			break
+		}
+		timer := time.NewTimer(p.delay(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			errs = append(errs, ctx.Err())
+		case <-timer.C:
+			continue
+		}
+		break
+	}
+	last := errs[len(errs)-1]
+	if len(errs) == 1 {
+		return last
+	}
+	re := new(RetryError)
+	for _, err := range errs {
+		var fe *FetchError
+		if errors.As(err, &fe) {
+			err = fe.Err
+		}
+		re.Attempts = append(re.Attempts, err)
+	}
+	fe, is := errs[0].(*FetchError)
+	if !is {
+		return re
+	}
+	cp := *fe
+	cp.Err = re
+	return &cp
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// delay returns how long to wait after the failed attempt (1 for the
+// first).
+func (p RetryPolicy) delay(attempt int, err error) time.Duration {
+	var he *HTTPError
+	if errors.As(err, &he) {
+		if d, ok := retryAfter(he.Header.Get(`Retry-After`)); ok {
+			if p.MaxDelay > 0 && d > p.MaxDelay {
+				d = p.MaxDelay
+			}
+			return d
+		}
+	}
+	d := p.BaseDelay
+	if d <= 0 {
+		d = 100 * time.Millisecond
+	}
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// retryAfter parses the value of a Retry-After header (seconds or an
+// HTTP date).
+func retryAfter(v string) (time.Duration, bool) {
+	if len(v) == 0 {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
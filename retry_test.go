@@ -0,0 +1,113 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleRetryPolicy() {
+
+	var count int
+	svr := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			count++
+			switch count {
+			case 1:
+				w.WriteHeader(http.StatusServiceUnavailable)
+			case 2:
+				w.Header().Set(`Retry-After`, `0`)
+				w.WriteHeader(http.StatusTooManyRequests)
+			default:
+				fmt.Fprint(w, `finally`)
+			}
+		}))
+	defer svr.Close()
+
+	g := &get.Getter{
+		Retry: get.RetryPolicy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+			Jitter:      0.5,
+		},
+	}
+
+	fmt.Println(g.String(svr.URL))
+	fmt.Println(count)
+
+	// Output:
+	// finally <nil>
+	// 3
+}
+
+func ExampleRetryError() {
+
+	svr := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `down`, http.StatusBadGateway)
+		}))
+	defer svr.Close()
+
+	g := &get.Getter{
+		Retry: get.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	}
+
+	_, err := g.String(`http.head:` + svr.URL)
+
+	var re *get.RetryError
+	if errors.As(err, &re) {
+		fmt.Println(len(re.Attempts))
+		fmt.Println(re)
+	}
+
+	var he *get.HTTPError
+	fmt.Println(errors.As(err, &he), he.StatusCode)
+
+	// also without support for Unwrap() []error (before Go 1.20)
+	he = nil
+	fmt.Println(re.As(&he), he.StatusCode)
+
+	// Output:
+	// 3
+	// 3 attempts: 502 Bad Gateway: down; 502 Bad Gateway: down; 502 Bad Gateway: down
+	// true 502
+	// true 502
+}
+
+func ExampleRetryPolicy_ssh() {
+
+	tmp, _ := os.MkdirTemp(``, `flaky`)
+	defer os.RemoveAll(tmp)
+	count := filepath.Join(tmp, `count`)
+	os.WriteFile(count, []byte("1\n2\n"), 0600)
+	os.Setenv(`FLAKY_COUNT`, count)
+	defer os.Unsetenv(`FLAKY_COUNT`)
+
+	g := &get.Getter{
+		SSHPath: `testdata/flakyssh`,
+		Retry:   get.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	}
+
+	fmt.Println(g.String(`ssh.head://localhost/testdata/datafile`))
+
+	os.WriteFile(count, []byte("1\n2\n"), 0600)
+	fmt.Println(g.String(`ssh.trim://localhost/testdata/somefile`))
+
+	os.WriteFile(count, []byte("1\n2\n3\n"), 0600)
+	_, err := g.String(`ssh.tail://localhost/testdata/datafile`)
+	fmt.Println(err)
+
+	// Output:
+	// first line <nil>
+	// something <nil>
+	// get: ssh.tail: ssh localhost: 3 attempts: exit status 255: ssh: connect to host example.com port 22: Connection reset; exit status 255: ssh: connect to host example.com port 22: Connection reset; exit status 255: ssh: connect to host example.com port 22: Connection reset
+}
@@ -0,0 +1,198 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+func init() {
+	Register(`env`, FetcherFunc(
+		func(_ context.Context, g *Getter, value string) (string, error) {
+			return g.env(value)
+		}))
+	Register(`env.file`, fileFetcher(
+		func(g *Getter, value string) (string, error) {
+			return g.env(value)
+		}))
+	Register(`file`, localFile)
+	Register(`home`, fileFetcher(inDir((*Getter).homeDir)))
+	Register(`conf`, fileFetcher(inDir((*Getter).confDir)))
+	Register(`cache`, fileFetcher(inDir((*Getter).cacheDir)))
+	Register(`embed`, embedFetcher{})
+	Register(`scp`, scpFetcher{})
+	Register(`ssh`, sshFetcher{})
+	Register(`http`, httpFetcher(`http`))
+	Register(`https`, httpFetcher(`https`))
+	register(`dotenv`, dotenvFetcher{})
+	Register(`netrc`, FetcherFunc(
+		func(ctx context.Context, g *Getter, value string) (string, error) {
+			return g.netrc(ctx, value)
+		}))
+}
+
+// inDir returns a function that joins the value to the directory
+// returned by dir (homeDir, for example).
+func inDir(dir func(*Getter) (string, error)) fileFetcher {
+	return func(g *Getter, value string) (string, error) {
+		d, err := dir(g)
+		if err != nil {
+			return ``, err
+		}
+		return path.Join(d, value), nil
+	}
+}
+
+// fileFetcher fetches local files from the path it derives from the
+// value.
+type fileFetcher func(g *Getter, value string) (string, error)
+
+// localFile uses the value itself as the path.
+var localFile = fileFetcher(func(_ *Getter, value string) (string, error) {
+	return value, nil
+})
+
+func (f fileFetcher) Open(ctx context.Context, g *Getter, value string) (io.ReadCloser, error) {
+	path, err := f(g, value)
+	if err != nil {
+		return nil, err
+	}
+	return g.openLimited(ctx, path)
+}
+
+// Lines streams the local file keeping only the selected lines in
+// memory so that (unlike Open) no MaxSize applies (ex: file.tail on
+// a large log).
+func (f fileFetcher) Lines(ctx context.Context, g *Getter, value string, sel Lines) (string, error) {
+	path, err := f(g, value)
+	if err != nil {
+		return ``, err
+	}
+	file, err := g.openFile(ctx, path)
+	if err != nil {
+		return ``, err
+	}
+	defer file.Close()
+	line, err := selectLines(ctx, file, sel)
+	return line, fetchErr(`read`, path, err)
+}
+
+// embedFetcher is a file fetcher that uses the Embed file system of
+// the Getter instead of FS.
+type embedFetcher struct{}
+
+func (embedFetcher) getter(g *Getter) (*Getter, error) {
+	if g.Embed == nil {
+		return nil, &FetchError{Op: `read`, Err: fmt.Errorf(`%w: no Embed file system`, ErrNotFound)}
+	}
+	eg := *g
+	eg.FS = g.Embed
+	return &eg, nil
+}
+
+func (f embedFetcher) Open(ctx context.Context, g *Getter, value string) (io.ReadCloser, error) {
+	eg, err := f.getter(g)
+	if err != nil {
+		return nil, err
+	}
+	return localFile.Open(ctx, eg, value)
+}
+
+func (f embedFetcher) Lines(ctx context.Context, g *Getter, value string, sel Lines) (string, error) {
+	eg, err := f.getter(g)
+	if err != nil {
+		return ``, err
+	}
+	return localFile.Lines(ctx, eg, value, sel)
+}
+
+// scpFetcher copies the remote file into a temporary directory that is
+// removed when closed.
+type scpFetcher struct{}
+
+func (scpFetcher) Open(ctx context.Context, g *Getter, value string) (io.ReadCloser, error) {
+	dir, err := g.RemoteSCPContext(ctx, `scp:`+value, ``)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	path, err := FirstFileIn(dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fetchErr(`read`, path, err)
+	}
+	return g.limitFile(&tempFile{f, dir}, path)
+}
+
+// sshFetcher uses the remote cat, head, tail, and sed commands so that
+// only the data needed is transferred.
+type sshFetcher struct{}
+
+func (f sshFetcher) Open(ctx context.Context, g *Getter, value string) (io.ReadCloser, error) {
+	u, err := parseSSHPath(`ssh:` + value)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := g.sshPipe(ctx, u.Addr, `cat `+shellPath(u.Path))
+	if err != nil {
+		return nil, err
+	}
+	return g.limit(rc, -1)
+}
+
+func (f sshFetcher) Lines(ctx context.Context, g *Getter, value string, sel Lines) (string, error) {
+	u, err := parseSSHPath(`ssh:` + value)
+	if err != nil {
+		return ``, err
+	}
+	path := shellPath(u.Path)
+	var cmd string
+	switch {
+	case sel.Start == 1:
+		cmd = fmt.Sprintf(`head -n %v %v`, sel.End, path)
+	case sel.End == -1:
+		cmd = fmt.Sprintf(`tail -n %v %v`, -sel.Start, path)
+	case sel.Start > 0:
+		cmd = fmt.Sprintf(`sed -n '%v,%vp;%vq' %v`, sel.Start, sel.End, sel.End, path)
+	default:
+		cmd = fmt.Sprintf(`tail -n %v %v | head -n %v`, -sel.Start, path, sel.End-sel.Start+1)
+	}
+	out, err := g.SSHOutContext(ctx, u.Addr, cmd)
+	if err != nil {
+		return ``, err
+	}
+	// same line endings as local
+	return selectLines(ctx, strings.NewReader(out), Lines{1, len(out)})
+}
+
+// httpFetcher is the URL scheme (http or https) to fetch with.
+type httpFetcher string
+
+func (f httpFetcher) Open(ctx context.Context, g *Getter, value string) (io.ReadCloser, error) {
+	return g.openHTTP(ctx, f.url(value))
+}
+
+func (f httpFetcher) Lines(ctx context.Context, g *Getter, value string, sel Lines) (string, error) {
+	return g.lineOfHTTP(ctx, f.url(value), sel)
+}
+
+// url returns the full URL for the value, which may already be a full
+// URL (ex: https.json:https://host/meta) or just the part after the
+// scheme (ex: https://host/meta).
+func (f httpFetcher) url(value string) string {
+	if strings.HasPrefix(value, `http://`) || strings.HasPrefix(value, `https://`) {
+		return value
+	}
+	return string(f) + `:` + value
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/rwxrob/get"
+)
+
+// bigLog returns lines "line 1" through "line n" each ending with
+// a line feed.
+func bigLog(n int) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "line %v\n", i)
+	}
+	return b.String()
+}
+
+func ExampleLastLineOfHTTP_ranges() {
+
+	content := bigLog(10000) // 88894 bytes
+	var requests []string
+	svr := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, strings.TrimSpace(r.Method+` `+r.Header.Get(`Range`)))
+			http.ServeContent(w, r, `app.log`, time.Time{}, strings.NewReader(content))
+		}))
+	defer svr.Close()
+
+	fmt.Println(get.LastLineOfHTTP(svr.URL))
+	fmt.Println(requests)
+
+	requests = nil
+	lines, err := get.String(`http.tail.3000:` + svr.URL)
+	fmt.Println(strings.Count(lines, "\n")+1, lines[:9], err)
+	fmt.Println(requests)
+
+	// Output:
+	// line 10000 <nil>
+	// [HEAD GET bytes=-4096]
+	// 3000 line 7001 <nil>
+	// [HEAD GET bytes=-4096 GET bytes=-16384 GET bytes=-65536]
+}
+
+func ExampleLastLineOfHTTP_no_ranges() {
+
+	content := bigLog(10000)
+	var requests []string
+	svr := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, strings.TrimSpace(r.Method+` `+r.Header.Get(`Range`)))
+			w.Header().Set(`Content-Length`, fmt.Sprint(len(content)))
+			if r.Method == `HEAD` {
+				return
+			}
+			fmt.Fprint(w, content)
+		}))
+	defer svr.Close()
+
+	fmt.Println(get.LastLineOfHTTP(svr.URL))
+	fmt.Println(requests)
+
+	// Output:
+	// line 10000 <nil>
+	// [HEAD GET]
+}
+
+func ExampleLastLineOfHTTP_ignored_ranges() {
+
+	// claims to support ranges but always sends everything
+	content := bigLog(10000)
+	svr := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(`Accept-Ranges`, `bytes`)
+			w.Header().Set(`Content-Length`, fmt.Sprint(len(content)))
+			if r.Method == `HEAD` {
+				return
+			}
+			fmt.Fprint(w, content)
+		}))
+	defer svr.Close()
+
+	fmt.Println(get.String(`http.tail.2:` + svr.URL))
+
+	// Output:
+	// line 9999
+	// line 10000 <nil>
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Target is a parsed target string. Every target has the following
+// grammar:
+//
+//	target    = plain | source *( "." transform ) ":" value [ "#" selector ]
+//	source    = registered schema name (see Register and Schemes)
+//	transform = modifier *( "." argument )
+//	plain     = anything without a registered source before the first ":"
+//
+// The source is the longest registered schema name (which may itself
+// contain dots, such as env.file) at the start of the target. Each
+// transform is the name of a modifier (head, tail, line, lines, json,
+// yaml, toml, dotenv, ini, re, b64, b64url, hex, gunzip, trim) followed
+// by any arguments it takes (ex: head.3, lines.2-5, re.token). Since
+// modifier names are reserved, any dotted part that is not one is an
+// argument to the modifier before it. Transforms are applied in order
+// from left to right.
+//
+// The value is everything after the first colon. Only when one of the
+// transforms uses a selector (json, yaml, toml, dotenv, ini, re) is the
+// value split at the first # into the value and selector. Otherwise,
+// any # remains a part of the value (as for the dotenv and netrc
+// sources, which look up the key after the # themselves).
+//
+// The bare head and tail sources are shortcuts for file.head and
+// file.tail. A target without a colon or a registered source is plain
+// and has only a Value (the entire string).
+type Target struct {
+	Source     string   // registered schema name (ex: file, env.file)
+	Transforms []string // modifiers in order (ex: head.3, b64, json)
+	Value      string   // path, URL, or name (everything after the colon)
+	Selector   string   // after the first # when a transform uses one
+}
+
+// ParseTarget parses the target (see Target for the grammar). Returns
+// a FetchError with ErrInvalidURI if the source is registered but any
+// of the transforms are unknown or have invalid arguments.
+func ParseTarget(target string) (*Target, error) {
+	t, _, _, err := parseTarget(target)
+	return t, err
+}
+
+// String returns the target in its canonical string form, which may
+// differ from the string that was parsed (ex: file.head for head,
+// head for head.1).
+func (t Target) String() string {
+	if len(t.Source) == 0 {
+		return t.Value
+	}
+	s := strings.Join(append([]string{t.Source}, t.Transforms...), `.`)
+	s += `:` + t.Value
+	if len(t.Selector) > 0 {
+		s += `#` + t.Selector
+	}
+	return s
+}
+
+// parseTarget returns the Target along with its Fetcher and parsed
+// modifiers. The Fetcher is nil for a plain target.
+func parseTarget(target string) (*Target, Fetcher, []modifier, error) {
+	schema, value, found := strings.Cut(target, `:`)
+	if !found {
+		return &Target{Value: target}, nil, nil, nil
+	}
+	parts := strings.Split(schema, `.`)
+	switch parts[0] {
+	case `head`, `tail`:
+		parts = append([]string{`file`}, parts...)
+	}
+	var firstErr error
+	for i := len(parts); i > 0; i-- {
+		source := strings.Join(parts[:i], `.`)
+		f := registered(source)
+		if f == nil {
+			continue
+		}
+		mods, err := parseModifiers(parts[i:])
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		t := &Target{Source: source}
+		t.Value, t.Selector = splitSelector(value, mods)
+		for _, m := range mods {
+			t.Transforms = append(t.Transforms, m.name)
+		}
+		return t, f, mods, nil
+	}
+	if firstErr != nil {
+		return nil, nil, nil, &FetchError{
+			Schema: schema,
+			Target: Sanitize(target),
+			Op:     `parse`,
+			Err:    fmt.Errorf(`%w: %v`, ErrInvalidURI, firstErr),
+		}
+	}
+	return &Target{Value: target}, nil, nil, nil
+}
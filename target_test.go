@@ -0,0 +1,91 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleParseTarget() {
+
+	for _, target := range []string{
+		`conf.json:gcloud/creds.json#client_secret`,
+		`env.b64.trim:KUBE_TOKEN`,
+		`ssh.tail.3:user@host:/var/log/app.log`,
+		`env.file.head:TOKEN_FILE`,
+		`head:/etc/hostname`,
+		`https://example.com/page#section`,
+		`just a string`,
+	} {
+		t, err := get.ParseTarget(target)
+		fmt.Printf("%q %q %q %q %v\n", t.Source, t.Transforms, t.Value, t.Selector, err)
+	}
+
+	// Output:
+	// "conf" ["json"] "gcloud/creds.json" "client_secret" <nil>
+	// "env" ["b64" "trim"] "KUBE_TOKEN" "" <nil>
+	// "ssh" ["tail.3"] "user@host:/var/log/app.log" "" <nil>
+	// "env.file" ["head"] "TOKEN_FILE" "" <nil>
+	// "file" ["head"] "/etc/hostname" "" <nil>
+	// "https" [] "//example.com/page#section" "" <nil>
+	// "" [] "just a string" "" <nil>
+}
+
+func ExampleParseTarget_invalid() {
+
+	_, err := get.ParseTarget(`file.bogus:/etc/hostname`)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, get.ErrInvalidURI))
+
+	_, err = get.ParseTarget(`file.line.x:/etc/hostname`)
+	fmt.Println(err)
+
+	// Output:
+	// get: file.bogus: parse file.bogus:/etc/hostname: invalid URI: unknown modifier "bogus"
+	// true
+	// get: file.line.x: parse file.line.x:/etc/hostname: invalid URI: invalid modifier "line.x"
+}
+
+func ExampleTarget_String() {
+
+	fmt.Println(get.Target{
+		Source:     `conf`,
+		Transforms: []string{`head.3`, `yaml`},
+		Value:      `gh/hosts.yml`,
+		Selector:   `github.com.oauth_token`,
+	})
+
+	t, _ := get.ParseTarget(`tail.1:/var/log/app.log`)
+	fmt.Println(t)
+
+	fmt.Println(get.Target{Value: `just a string`})
+
+	// Output:
+	// conf.head.3.yaml:gh/hosts.yml#github.com.oauth_token
+	// file.tail:/var/log/app.log
+	// just a string
+}
+
+func ExampleString_invalid_modifier() {
+
+	// a typo is an error rather than the target itself
+	out, err := get.String(`file.haed:/etc/hostname`)
+	fmt.Printf("%q %v\n", out, err)
+	fmt.Println(errors.Is(err, get.ErrInvalidURI))
+
+	_, err = get.String(`file.line.0:x`)
+	fmt.Println(errors.Is(err, get.ErrInvalidURI))
+
+	// no registered source, so just a string
+	fmt.Println(get.String(`note.haed: remember the milk`))
+
+	// Output:
+	// "" get: file.haed: parse file.haed:/etc/hostname: invalid URI: unknown modifier "haed"
+	// true
+	// true
+	// note.haed: remember the milk <nil>
+}
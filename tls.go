@@ -0,0 +1,157 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// TLSOptions configure the TLS connections of every https request made
+// by a Getter. The CA, Cert, and Key are themselves targets (see String)
+// so that they may be kept anywhere (ex: conf:app/ca.pem). The zero
+// value uses the TLS configuration of the Client (or the defaults).
+type TLSOptions struct {
+
+	// CA is the target of a PEM bundle of certificate authorities to
+	// trust instead of those of the host system.
+	CA string
+
+	// Cert and Key are the targets of the PEM client certificate and
+	// private key for mutual TLS. When Key is empty, the key must be
+	// included with the certificate.
+	Cert string
+	Key  string
+
+	// MinVersion is the minimum TLS version accepted (ex:
+	// tls.VersionTLS13).
+	MinVersion uint16
+
+	// ServerName is used to verify the certificate of the server (and
+	// for SNI) instead of the host name of the URL.
+	ServerName string
+
+	// Pins are the base64-encoded SHA-256 hashes of the Subject Public
+	// Key Info of certificates, any one of which must be in a verified
+	// chain of the server (so pins never match when verification is
+	// skipped). Extra certificates sent by the server that are not part
+	// of a verified chain are ignored. An optional sha256/ prefix is
+	// allowed (ex: sha256/x4o3...=). See SPKIPin.
+	Pins []string
+}
+
+func (o TLSOptions) isZero() bool {
+	return len(o.CA) == 0 && len(o.Cert) == 0 && len(o.Key) == 0 &&
+		o.MinVersion == 0 && len(o.ServerName) == 0 && len(o.Pins) == 0
+}
+
+// SPKIPin returns the pin (base64-encoded SHA-256 hash of the Subject
+// Public Key Info) of the certificate for use with TLSOptions.Pins.
+func SPKIPin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// httpClient returns the Client of the Getter (or the default) with the
+// TLSOptions applied to a copy of its transport, which is only used for
+// a single fetch (see httpFetch).
+func (g *Getter) httpClient(ctx context.Context) (*http.Client, error) {
+	if g.TLS.isZero() {
+		return g.client(), nil
+	}
+	base := g.client()
+	tr, is := base.Transport.(*http.Transport)
+	if !is || tr == nil {
+		if base.Transport != nil {
+			return nil, &FetchError{Op: `tls`,
+				Err: fmt.Errorf(`TLS options require the Client Transport to be an *http.Transport`)}
+		}
+		tr = http.DefaultTransport.(*http.Transport)
+	}
+	tr = tr.Clone()
+
+	conf, err := g.tlsConfig(ctx, tr.TLSClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	tr.TLSClientConfig = conf
+	client := *base
+	client.Transport = tr
+	return &client, nil
+}
+
+// tlsConfig returns a copy of conf (which may be nil) with the
+// TLSOptions of the Getter applied.
+func (g *Getter) tlsConfig(ctx context.Context, conf *tls.Config) (*tls.Config, error) {
+	opts := g.TLS
+	if conf == nil {
+		conf = new(tls.Config)
+	} else {
+		conf = conf.Clone()
+	}
+
+	if len(opts.CA) > 0 {
+		pem, err := g.credential(ctx, opts.CA)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(pem)) {
+			return nil, &FetchError{Op: `tls`, Target: Sanitize(opts.CA),
+				Err: fmt.Errorf(`%w: no PEM certificates in CA`, ErrInvalidURI)}
+		}
+		conf.RootCAs = pool
+	}
+
+	if len(opts.Cert) > 0 {
+		cert, err := g.credential(ctx, opts.Cert)
+		if err != nil {
+			return nil, err
+		}
+		key := cert
+		if len(opts.Key) > 0 {
+			if key, err = g.credential(ctx, opts.Key); err != nil {
+				return nil, err
+			}
+		}
+		pair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, &FetchError{Op: `tls`, Target: Sanitize(opts.Cert),
+				Err: fmt.Errorf(`%w: %v`, ErrInvalidURI, err)}
+		}
+		conf.Certificates = []tls.Certificate{pair}
+	}
+
+	if opts.MinVersion > 0 {
+		conf.MinVersion = opts.MinVersion
+	}
+	if len(opts.ServerName) > 0 {
+		conf.ServerName = opts.ServerName
+	}
+
+	if len(opts.Pins) > 0 {
+		pins := map[string]bool{}
+		for _, p := range opts.Pins {
+			pins[strings.TrimPrefix(p, `sha256/`)] = true
+		}
+		conf.VerifyConnection = func(cs tls.ConnectionState) error {
+			// only verified chains since any certificate may be sent
+			for _, chain := range cs.VerifiedChains {
+				for _, cert := range chain {
+					if pins[SPKIPin(cert)] {
+						return nil
+					}
+				}
+			}
+			return fmt.Errorf(`no verified certificate matches the pinned public keys`)
+		}
+	}
+	return conf, nil
+}
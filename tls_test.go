@@ -0,0 +1,317 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing/fstest"
+	"time"
+
+	"github.com/rwxrob/get"
+)
+
+// testCert is a locally generated certificate and key (signed by the
+// parent or self-signed when parent is nil).
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(parent *testCert, name string, ca bool) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth,
+		},
+	}
+	if ca {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		tmpl.DNSNames = []string{name}
+	}
+	signer, signKey := tmpl, key
+	if parent != nil {
+		signer, signKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signKey)
+	if err != nil {
+		panic(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: `CERTIFICATE`, Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: `EC PRIVATE KEY`, Bytes: keyDER}),
+	}
+}
+
+func (c *testCert) pair() tls.Certificate {
+	pair, err := tls.X509KeyPair(c.certPEM, c.keyPEM)
+	if err != nil {
+		panic(err)
+	}
+	return pair
+}
+
+func ExampleTLSOptions() {
+
+	ca := newTestCert(nil, `Internal CA`, true)
+	server := newTestCert(ca, `internal.example`, false)
+
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `hello`)
+		}))
+	svr.TLS = &tls.Config{Certificates: []tls.Certificate{server.pair()}}
+	svr.StartTLS()
+	defer svr.Close()
+
+	fs := fstest.MapFS{`conf/app/ca.pem`: {Data: ca.certPEM}}
+
+	// the internal CA is unknown to the system
+	g := &get.Getter{ConfDir: `/conf`, FS: fs}
+	_, err := g.HTTPContext(context.Background(), svr.URL)
+	fmt.Println(err != nil)
+
+	// the certificate is not for 127.0.0.1
+	g.TLS.CA = `conf:app/ca.pem`
+	_, err = g.HTTPContext(context.Background(), svr.URL)
+	fmt.Println(err != nil)
+
+	g.TLS.ServerName = `internal.example`
+	fmt.Println(g.String(`https:` + svr.URL))
+
+	// Output:
+	// true
+	// true
+	// hello <nil>
+}
+
+func ExampleTLSOptions_client() {
+
+	ca := newTestCert(nil, `Internal CA`, true)
+	server := newTestCert(ca, `internal.example`, false)
+	client := newTestCert(ca, `myapp`, false)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, r.TLS.PeerCertificates[0].Subject.CommonName)
+		}))
+	svr.TLS = &tls.Config{
+		Certificates: []tls.Certificate{server.pair()},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
+	svr.StartTLS()
+	defer svr.Close()
+
+	g := &get.Getter{
+		ConfDir: `/conf`,
+		FS: fstest.MapFS{
+			`conf/app/ca.pem`:  {Data: ca.certPEM},
+			`conf/app/crt.pem`: {Data: client.certPEM},
+			`conf/app/key.pem`: {Data: client.keyPEM},
+		},
+		TLS: get.TLSOptions{
+			CA:         `conf:app/ca.pem`,
+			ServerName: `internal.example`,
+		},
+	}
+
+	// no client certificate
+	_, err := g.HTTPContext(context.Background(), svr.URL)
+	fmt.Println(err != nil)
+
+	g.TLS.Cert = `conf:app/crt.pem`
+	g.TLS.Key = `conf:app/key.pem`
+	fmt.Println(g.String(`https:` + svr.URL))
+
+	// the key is missing
+	g.TLS.Key = `conf:app/nope.pem`
+	_, err = g.HTTPContext(context.Background(), svr.URL)
+	fmt.Println(errors.Is(err, get.ErrNotFound))
+
+	// Output:
+	// true
+	// myapp <nil>
+	// true
+}
+
+func ExampleTLSOptions_pins() {
+
+	svr := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `pinned`)
+		}))
+	defer svr.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(svr.Certificate())
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+	}
+
+	g := &get.Getter{
+		Client: client,
+		TLS:    get.TLSOptions{Pins: []string{`sha256/` + get.SPKIPin(svr.Certificate())}},
+	}
+	fmt.Println(g.String(`https:` + svr.URL))
+
+	other := newTestCert(nil, `other`, false)
+	g.TLS.Pins = []string{get.SPKIPin(other.cert)}
+	_, err := g.HTTPContext(context.Background(), svr.URL)
+	fmt.Println(err != nil)
+
+	// Output:
+	// pinned <nil>
+	// true
+}
+
+func ExampleTLSOptions_minVersion() {
+
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `ok`)
+		}))
+	svr.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	svr.StartTLS()
+	defer svr.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(svr.Certificate())
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+	}
+
+	g := &get.Getter{Client: client}
+	fmt.Println(g.String(`https:` + svr.URL))
+
+	g.TLS.MinVersion = tls.VersionTLS13
+	_, err := g.HTTPContext(context.Background(), svr.URL)
+	fmt.Println(err != nil)
+
+	// Output:
+	// ok <nil>
+	// true
+}
+
+func ExampleTLSOptions_pins_unverified() {
+
+	ca := newTestCert(nil, `Internal CA`, true)
+	real := newTestCert(ca, `internal.example`, false)
+	rogue := newTestCert(ca, `internal.example`, false)
+
+	// the rogue certificate is trusted but sends the pinned one as extra
+	pair := rogue.pair()
+	pair.Certificate = append(pair.Certificate, real.cert.Raw)
+
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `intercepted`)
+		}))
+	svr.TLS = &tls.Config{Certificates: []tls.Certificate{pair}}
+	svr.StartTLS()
+	defer svr.Close()
+
+	g := &get.Getter{
+		FS: fstest.MapFS{`ca.pem`: {Data: ca.certPEM}},
+		TLS: get.TLSOptions{
+			CA:         `file:ca.pem`,
+			ServerName: `internal.example`,
+		},
+	}
+	fmt.Println(g.String(`https:` + svr.URL))
+
+	g.TLS.Pins = []string{get.SPKIPin(real.cert)}
+	_, err := g.HTTPContext(context.Background(), svr.URL)
+	fmt.Println(err != nil)
+
+	g.TLS.Pins = []string{get.SPKIPin(ca.cert)}
+	fmt.Println(g.String(`https:` + svr.URL))
+
+	// Output:
+	// intercepted <nil>
+	// true
+	// intercepted <nil>
+}
+
+func ExampleTLSOptions_reuse() {
+
+	var tokens int
+	auth := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			tokens++
+			fmt.Fprint(w, `short-lived`)
+		}))
+	defer auth.Close()
+
+	content := bigLog(2000)
+	var requests []string
+	var conns int
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, strings.TrimSpace(r.Method+` `+r.Header.Get(`Range`)))
+			if r.Header.Get(`Authorization`) != `Bearer short-lived` {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			http.ServeContent(w, r, `app.log`, time.Time{}, strings.NewReader(content))
+		}))
+	svr.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns++
+		}
+	}
+	svr.StartTLS()
+	defer svr.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: `CERTIFICATE`, Bytes: svr.Certificate().Raw})
+	g := &get.Getter{
+		FS:   fstest.MapFS{`ca.pem`: {Data: ca}},
+		HTTP: get.HTTPOptions{Bearer: auth.URL},
+		TLS:  get.TLSOptions{CA: `file:ca.pem`},
+	}
+
+	// one token and one connection for every request of the fetch
+	out, err := g.String(`https.tail.1500:` + svr.URL)
+	fmt.Println(strings.Count(out, "\n")+1, err)
+	fmt.Println(requests)
+	fmt.Println(tokens, conns)
+
+	// Output:
+	// 1500 <nil>
+	// [HEAD GET bytes=-4096 GET bytes=-16384]
+	// 1 1
+}
@@ -0,0 +1,446 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// selectTOML returns the scalar value at the dotted key path within the
+// TOML data (see selectKey).
+func selectTOML(in []byte, selector string) ([]byte, error) {
+	data, err := parseTOML(string(in))
+	if err != nil {
+		return nil, err
+	}
+	return selectKey(data, selector)
+}
+
+// parseTOML parses TOML into maps, lists, and strings. Tables, arrays
+// of tables, dotted and quoted keys, all four string types, arrays, and
+// inline tables are supported. Numbers, booleans, and dates are kept as
+// written.
+func parseTOML(text string) (any, error) {
+	p := &tomlParser{s: text, line: 1}
+	root := map[string]any{}
+	cur := root
+	for {
+		p.skipSpace(true)
+		if p.done() {
+			return root, nil
+		}
+		var err error
+		switch {
+		case strings.HasPrefix(p.rest(), `[[`):
+			cur, err = p.table(root, true)
+		case p.peek() == '[':
+			cur, err = p.table(root, false)
+		default:
+			err = p.keyValue(cur)
+		}
+		if err == nil {
+			err = p.endOfLine()
+		}
+		if err != nil {
+			return nil, fmt.Errorf(`toml: line %v: %w`, p.line, err)
+		}
+	}
+}
+
+type tomlParser struct {
+	s    string
+	pos  int
+	line int
+}
+
+func (p *tomlParser) done() bool   { return p.pos >= len(p.s) }
+func (p *tomlParser) rest() string { return p.s[p.pos:] }
+
+func (p *tomlParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *tomlParser) advance(n int) {
+	p.line += strings.Count(p.s[p.pos:p.pos+n], "\n")
+	p.pos += n
+}
+
+// skipSpace skips spaces and tabs and, if lines is true, line endings
+// and comments as well.
+func (p *tomlParser) skipSpace(lines bool) {
+	for !p.done() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t':
+			p.advance(1)
+		case lines && (c == '\n' || c == '\r'):
+			p.advance(1)
+		case lines && c == '#':
+			p.skipComment()
+		default:
+			return
+		}
+	}
+}
+
+func (p *tomlParser) skipComment() {
+	if end := strings.IndexByte(p.rest(), '\n'); end >= 0 {
+		p.advance(end)
+	} else {
+		p.advance(len(p.rest()))
+	}
+}
+
+// endOfLine requires that nothing but a comment follows on the line.
+func (p *tomlParser) endOfLine() error {
+	p.skipSpace(false)
+	if p.peek() == '#' {
+		p.skipComment()
+	}
+	switch {
+	case p.done():
+	case strings.HasPrefix(p.rest(), "\n"):
+		p.advance(1)
+	case strings.HasPrefix(p.rest(), "\r\n"):
+		p.advance(2)
+	default:
+		return fmt.Errorf(`unexpected %q`, p.line1())
+	}
+	return nil
+}
+
+// line1 returns the rest of the current line for error messages.
+func (p *tomlParser) line1() string {
+	rest := p.rest()
+	if end := strings.IndexByte(rest, '\n'); end >= 0 {
+		rest = rest[:end]
+	}
+	return strings.TrimSpace(rest)
+}
+
+// table parses a [table] or [[array]] header and returns the table
+// that following key/value pairs are to be added to.
+func (p *tomlParser) table(root map[string]any, array bool) (map[string]any, error) {
+	open, end := `[`, `]`
+	if array {
+		open, end = `[[`, `]]`
+	}
+	p.advance(len(open))
+	keys, err := p.key()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(p.rest(), end) {
+		return nil, fmt.Errorf(`expected %v`, end)
+	}
+	p.advance(len(end))
+	parent, err := tomlTable(root, keys[:len(keys)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := keys[len(keys)-1]
+	if !array {
+		return tomlTable(parent, []string{last})
+	}
+	list, _ := parent[last].([]any)
+	if _, exists := parent[last]; exists && list == nil {
+		return nil, fmt.Errorf(`%v is not an array of tables`, last)
+	}
+	t := map[string]any{}
+	parent[last] = append(list, t)
+	return t, nil
+}
+
+// tomlTable returns the table at the keys within t creating any that
+// do not yet exist. The last table of an array of tables is used.
+func tomlTable(t map[string]any, keys []string) (map[string]any, error) {
+	for _, k := range keys {
+		switch v := t[k].(type) {
+		case nil:
+			next := map[string]any{}
+			t[k] = next
+			t = next
+		case map[string]any:
+			t = v
+		case []any:
+			last, is := v[len(v)-1].(map[string]any)
+			if !is {
+				return nil, fmt.Errorf(`%v is not a table`, k)
+			}
+			t = last
+		default:
+			return nil, fmt.Errorf(`%v is not a table`, k)
+		}
+	}
+	return t, nil
+}
+
+func (p *tomlParser) keyValue(t map[string]any) error {
+	keys, err := p.key()
+	if err != nil {
+		return err
+	}
+	if p.peek() != '=' {
+		return fmt.Errorf(`expected = after key %v`, strings.Join(keys, `.`))
+	}
+	p.advance(1)
+	p.skipSpace(false)
+	val, err := p.value()
+	if err != nil {
+		return err
+	}
+	t, err = tomlTable(t, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	last := keys[len(keys)-1]
+	if _, exists := t[last]; exists {
+		return fmt.Errorf(`duplicate key %v`, last)
+	}
+	t[last] = val
+	return nil
+}
+
+// key parses a (possibly dotted and quoted) key.
+func (p *tomlParser) key() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpace(false)
+		var k string
+		var err error
+		switch p.peek() {
+		case '"':
+			k, err = p.basic()
+		case '\'':
+			k, err = p.literal()
+		default:
+			end := p.pos
+			for end < len(p.s) && isTOMLBare(p.s[end]) {
+				end++
+			}
+			if end == p.pos {
+				return nil, fmt.Errorf(`invalid key: %v`, p.line1())
+			}
+			k = p.s[p.pos:end]
+			p.advance(end - p.pos)
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+		p.skipSpace(false)
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.advance(1)
+	}
+}
+
+func isTOMLBare(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (p *tomlParser) value() (any, error) {
+	switch p.peek() {
+	case '"':
+		return p.basic()
+	case '\'':
+		return p.literal()
+	case '[':
+		return p.array()
+	case '{':
+		return p.inline()
+	}
+	end := p.pos
+	for end < len(p.s) && strings.IndexByte(" \t\r\n,]}#", p.s[end]) < 0 {
+		end++
+	}
+	// local date-times may use a space instead of T
+	if end+1 < len(p.s) && p.s[end] == ' ' && p.s[end+1] >= '0' && p.s[end+1] <= '9' &&
+		strings.Count(p.s[p.pos:end], `-`) == 2 {
+		for end++; end < len(p.s) && strings.IndexByte(" \t\r\n,]}#", p.s[end]) < 0; end++ {
+		}
+	}
+	if end == p.pos {
+		return nil, fmt.Errorf(`missing value`)
+	}
+	val := p.s[p.pos:end]
+	p.advance(end - p.pos)
+	return val, nil
+}
+
+func (p *tomlParser) array() (any, error) {
+	p.advance(1)
+	list := []any{}
+	for {
+		p.skipSpace(true)
+		if p.peek() == ']' {
+			p.advance(1)
+			return list, nil
+		}
+		val, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, val)
+		p.skipSpace(true)
+		switch p.peek() {
+		case ',':
+			p.advance(1)
+		case ']':
+		default:
+			return nil, fmt.Errorf(`expected , or ] in array`)
+		}
+	}
+}
+
+func (p *tomlParser) inline() (any, error) {
+	p.advance(1)
+	t := map[string]any{}
+	p.skipSpace(false)
+	if p.peek() == '}' {
+		p.advance(1)
+		return t, nil
+	}
+	for {
+		if err := p.keyValue(t); err != nil {
+			return nil, err
+		}
+		p.skipSpace(false)
+		switch p.peek() {
+		case ',':
+			p.advance(1)
+		case '}':
+			p.advance(1)
+			return t, nil
+		default:
+			return nil, fmt.Errorf(`expected , or } in inline table`)
+		}
+	}
+}
+
+// literal parses a single or multi-line literal (single-quoted) string.
+func (p *tomlParser) literal() (string, error) {
+	if strings.HasPrefix(p.rest(), `'''`) {
+		end := strings.Index(p.s[p.pos+3:], `'''`)
+		if end < 0 {
+			return ``, fmt.Errorf(`unterminated string`)
+		}
+		str := p.s[p.pos+3 : p.pos+3+end]
+		p.advance(end + 6)
+		return trimTOMLNewline(str), nil
+	}
+	end := strings.IndexAny(p.s[p.pos+1:], "'\n")
+	if end < 0 || p.s[p.pos+1+end] != '\'' {
+		return ``, fmt.Errorf(`unterminated string`)
+	}
+	str := p.s[p.pos+1 : p.pos+1+end]
+	p.advance(end + 2)
+	return str, nil
+}
+
+// basic parses a single or multi-line basic (double-quoted) string with
+// escapes.
+func (p *tomlParser) basic() (string, error) {
+	multi := strings.HasPrefix(p.rest(), `"""`)
+	quote := `"`
+	if multi {
+		quote = `"""`
+	}
+	p.advance(len(quote))
+	if multi {
+		if strings.HasPrefix(p.rest(), "\r\n") {
+			p.advance(2)
+		} else if strings.HasPrefix(p.rest(), "\n") {
+			p.advance(1)
+		}
+	}
+	var buf strings.Builder
+	for !p.done() {
+		rest := p.rest()
+		switch {
+
+		case strings.HasPrefix(rest, quote):
+			p.advance(len(quote))
+			return buf.String(), nil
+
+		case rest[0] == '\n' && !multi:
+			return ``, fmt.Errorf(`unterminated string`)
+
+		case rest[0] == '\\':
+			if multi && len(strings.TrimLeft(rest[1:], " \t\r")) > 0 &&
+				strings.TrimLeft(rest[1:], " \t\r")[0] == '\n' {
+				// line ending backslash trims the white space that follows
+				// (but a # after it is still part of the string)
+				p.advance(1)
+				p.advance(len(rest) - 1 - len(strings.TrimLeft(rest[1:], " \t\r\n")))
+				continue
+			}
+			r, n, err := tomlEscape(rest)
+			if err != nil {
+				return ``, err
+			}
+			buf.WriteRune(r)
+			p.advance(n)
+
+		default:
+			buf.WriteByte(rest[0])
+			p.advance(1)
+		}
+	}
+	return ``, fmt.Errorf(`unterminated string`)
+}
+
+// tomlEscape decodes the escape sequence at the start of s returning
+// the rune and the number of bytes used.
+func tomlEscape(s string) (rune, int, error) {
+	if len(s) < 2 {
+		return 0, 0, fmt.Errorf(`invalid escape`)
+	}
+	switch s[1] {
+	case 'b':
+		return '\b', 2, nil
+	case 't':
+		return '\t', 2, nil
+	case 'n':
+		return '\n', 2, nil
+	case 'f':
+		return '\f', 2, nil
+	case 'r':
+		return '\r', 2, nil
+	case 'e':
+		return '\x1b', 2, nil
+	case '"':
+		return '"', 2, nil
+	case '\\':
+		return '\\', 2, nil
+	case 'u', 'U':
+		n := 4
+		if s[1] == 'U' {
+			n = 8
+		}
+		if len(s) < 2+n {
+			return 0, 0, fmt.Errorf(`invalid escape: %v`, s)
+		}
+		code, err := strconv.ParseUint(s[2:2+n], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return 0, 0, fmt.Errorf(`invalid escape: %v`, s[:2+n])
+		}
+		return rune(code), 2 + n, nil
+	}
+	return 0, 0, fmt.Errorf(`invalid escape: %v`, s[:2])
+}
+
+func trimTOMLNewline(s string) string {
+	if strings.HasPrefix(s, "\r\n") {
+		return s[2:]
+	}
+	return strings.TrimPrefix(s, "\n")
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"errors"
+	"fmt"
+	"testing/fstest"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleString_toml() {
+
+	g := &get.Getter{
+		ConfDir: `/conf`,
+		FS: fstest.MapFS{
+			`conf/app/config.toml`: {Data: []byte(`# app settings
+title = "App"
+token = 'C:\not\escaped'
+
+[hosts."github.com"]
+oauth_token = "gho_\u0073ometoken" # keep secret
+
+[[servers]]
+name = "alpha"
+ports = [ 22,
+  443 ]
+
+[[servers]]
+name = "beta"
+auth = { user = "me", pass = """
+secret""" }
+
+[notes]
+text = """\
+   # not a comment, \
+
+   continued"""
+`)},
+		},
+	}
+
+	for _, target := range []string{
+		`conf.toml:app/config.toml#title`,
+		`conf.toml:app/config.toml#token`,
+		`conf.toml:app/config.toml#hosts.github.com.oauth_token`,
+		`conf.toml:app/config.toml#servers.0.ports.1`,
+		`conf.toml:app/config.toml#servers.1.auth.pass`,
+		`conf.toml:app/config.toml#notes.text`,
+	} {
+		fmt.Println(g.String(target))
+	}
+
+	// Output:
+	// App <nil>
+	// C:\not\escaped <nil>
+	// gho_sometoken <nil>
+	// 443 <nil>
+	// secret <nil>
+	// # not a comment, continued <nil>
+}
+
+func ExampleString_toml_errors() {
+
+	g := &get.Getter{
+		FS: fstest.MapFS{`app.toml`: {Data: []byte("[db]\nuser = \"me\"\n")}},
+	}
+
+	_, err := g.String(`file.toml:app.toml#db.password`)
+	fmt.Println(errors.Is(err, get.ErrNotFound))
+
+	_, err = g.String(`file.toml:app.toml#db`)
+	fmt.Println(errors.Is(err, get.ErrNotScalar))
+
+	// Output:
+	// true
+	// true
+}
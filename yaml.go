@@ -0,0 +1,415 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// selectYAML returns the scalar value at the dotted key path within the
+// YAML data (see selectKey).
+func selectYAML(in []byte, selector string) ([]byte, error) {
+	data, err := parseYAML(string(in))
+	if err != nil {
+		return nil, err
+	}
+	return selectKey(data, selector)
+}
+
+// parseYAML parses the common subset of YAML used for configuration
+// files into maps, lists, and strings (nil for null): block mappings
+// and sequences, plain and quoted scalars, literal (|) and folded (>)
+// block scalars, single-line flow collections ([a, b] and {k: v}), and
+// comments. Anchors, aliases, tags, and multiple documents are not
+// supported. Numbers and booleans are kept as written.
+func parseYAML(text string) (any, error) {
+	p := new(yamlParser)
+	for _, raw := range strings.Split(text, "\n") {
+		raw = strings.TrimRight(raw, "\r")
+		line := yamlLine{raw: raw}
+		trimmed := strings.TrimLeft(raw, ` `)
+		line.indent = len(raw) - len(trimmed)
+		line.text = strings.TrimSpace(yamlStripComment(trimmed))
+		if line.text == `---` || strings.HasPrefix(line.text, `%`) {
+			line.text = ``
+		}
+		p.lines = append(p.lines, line)
+	}
+	node, i, err := p.node(0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if i = p.skip(i); i < len(p.lines) {
+		return nil, fmt.Errorf(`yaml: line %v: unexpected indentation`, i+1)
+	}
+	return node, nil
+}
+
+type yamlLine struct {
+	indent int
+	text   string // without indentation or comment
+	raw    string // for block scalars
+}
+
+type yamlParser struct {
+	lines []yamlLine
+}
+
+// skip returns the index of the next line at or after i that is not
+// blank (or only a comment).
+func (p *yamlParser) skip(i int) int {
+	for i < len(p.lines) && len(p.lines[i].text) == 0 {
+		i++
+	}
+	return i
+}
+
+// node parses the mapping, sequence, or scalar starting at line i that
+// is indented at least min returning the index of the line after it.
+func (p *yamlParser) node(i, min int) (any, int, error) {
+	i = p.skip(i)
+	if i >= len(p.lines) || p.lines[i].indent < min {
+		return nil, i, nil
+	}
+	line := p.lines[i]
+	switch {
+	case isYAMLItem(line.text):
+		return p.sequence(i, line.indent)
+	case yamlKeyEnd(line.text) >= 0:
+		return p.mapping(i, line.indent)
+	}
+	v, err := yamlScalar(line.text)
+	if err != nil {
+		return nil, i, fmt.Errorf(`yaml: line %v: %w`, i+1, err)
+	}
+	return v, i + 1, nil
+}
+
+func (p *yamlParser) sequence(i, indent int) (any, int, error) {
+	list := []any{}
+	for {
+		i = p.skip(i)
+		if i >= len(p.lines) || p.lines[i].indent != indent || !isYAMLItem(p.lines[i].text) {
+			return list, i, nil
+		}
+		rest := strings.TrimLeft(p.lines[i].text[1:], ` `)
+		var item any
+		var err error
+		if len(rest) == 0 {
+			item, i, err = p.node(i+1, indent+1)
+		} else {
+			// the rest of the line becomes its own (more indented) line
+			// so that a mapping can continue on the lines that follow
+			off := indent + len(p.lines[i].text) - len(rest)
+			p.lines[i] = yamlLine{indent: off, text: rest, raw: p.lines[i].raw}
+			item, i, err = p.node(i, off)
+		}
+		if err != nil {
+			return nil, i, err
+		}
+		list = append(list, item)
+	}
+}
+
+func (p *yamlParser) mapping(i, indent int) (any, int, error) {
+	m := map[string]any{}
+	for {
+		i = p.skip(i)
+		if i >= len(p.lines) || p.lines[i].indent != indent || isYAMLItem(p.lines[i].text) {
+			return m, i, nil
+		}
+		text := p.lines[i].text
+		end := yamlKeyEnd(text)
+		if end < 0 {
+			return nil, i, fmt.Errorf(`yaml: line %v: expected key: %v`, i+1, text)
+		}
+		key, err := yamlKey(text[:end])
+		if err != nil {
+			return nil, i, fmt.Errorf(`yaml: line %v: %w`, i+1, err)
+		}
+		val := strings.TrimSpace(text[end+1:])
+
+		switch {
+
+		case len(val) == 0:
+			// sequences are allowed at the same indentation as the key
+			next := p.skip(i + 1)
+			if next < len(p.lines) && p.lines[next].indent == indent &&
+				isYAMLItem(p.lines[next].text) {
+				m[key], i, err = p.sequence(next, indent)
+			} else {
+				m[key], i, err = p.node(i+1, indent+1)
+			}
+
+		case val[0] == '|' || val[0] == '>':
+			m[key], i, err = p.block(i, indent, val)
+
+		default:
+			m[key], err = yamlScalar(val)
+			if err != nil {
+				err = fmt.Errorf(`yaml: line %v: %w`, i+1, err)
+			}
+			i++
+		}
+		if err != nil {
+			return nil, i, err
+		}
+	}
+}
+
+// block returns the literal (|) or folded (>) block scalar that follows
+// the key on line i. The trailing line feed is kept unless the
+// indicator includes the strip (-) chomping indicator.
+func (p *yamlParser) block(i, indent int, indicator string) (string, int, error) {
+	var lines []string
+	min := -1
+	for i++; i < len(p.lines); i++ {
+		line := p.lines[i]
+		if len(strings.TrimSpace(line.raw)) == 0 {
+			lines = append(lines, ``)
+			continue
+		}
+		if line.indent <= indent {
+			break
+		}
+		if min < 0 {
+			min = line.indent
+		}
+		if line.indent < min {
+			return ``, i, fmt.Errorf(`yaml: line %v: block less indented than its first line`, i+1)
+		}
+		lines = append(lines, line.raw[min:])
+	}
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	val := strings.Join(lines, "\n")
+	if indicator[0] == '>' {
+		val = yamlFold(lines)
+	}
+	if !strings.Contains(indicator, `-`) && len(val) > 0 {
+		val += "\n"
+	}
+	return val, i, nil
+}
+
+// yamlFold joins the lines of a folded block scalar. Each line break
+// between two lines of text becomes a space unless followed by blank
+// lines, which are kept as line feeds instead. Lines that are more
+// indented than the block are never folded.
+func yamlFold(lines []string) string {
+	var buf strings.Builder
+	var blank int
+	var started, prevMore bool
+	for _, line := range lines {
+		if len(line) == 0 {
+			blank++
+			continue
+		}
+		more := line[0] == ' ' || line[0] == '\t'
+		switch {
+		case !started:
+		case blank == 0 && !more && !prevMore:
+			buf.WriteByte(' ')
+		case more || prevMore:
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(strings.Repeat("\n", blank))
+		buf.WriteString(line)
+		started, prevMore, blank = true, more, 0
+	}
+	return buf.String()
+}
+
+func isYAMLItem(text string) bool {
+	return text == `-` || strings.HasPrefix(text, `- `)
+}
+
+// yamlKeyEnd returns the index of the colon ending the key of a mapping
+// entry or -1 if the text is not one.
+func yamlKeyEnd(text string) int {
+	if len(text) > 0 && (text[0] == '"' || text[0] == '\'') {
+		end := strings.IndexByte(text[1:], text[0])
+		if end < 0 {
+			return -1
+		}
+		rest := text[end+2:]
+		if rest == `:` || strings.HasPrefix(rest, `: `) {
+			return end + 2
+		}
+		return -1
+	}
+	if len(text) > 0 && (text[0] == '[' || text[0] == '{') {
+		return -1
+	}
+	if i := strings.Index(text, `: `); i >= 0 {
+		return i
+	}
+	if strings.HasSuffix(text, `:`) {
+		return len(text) - 1
+	}
+	return -1
+}
+
+func yamlKey(key string) (string, error) {
+	v, err := yamlScalar(strings.TrimSpace(key))
+	if err != nil {
+		return ``, err
+	}
+	str, _ := v.(string)
+	return str, nil
+}
+
+// yamlScalar returns the (unquoted) string or flow collection for the
+// value.
+func yamlScalar(val string) (any, error) {
+	if len(val) == 0 {
+		return nil, nil
+	}
+	switch val[0] {
+	case '"', '\'', '[', '{':
+		v, rest, err := yamlFlow(val)
+		if err != nil {
+			return nil, err
+		}
+		if len(strings.TrimSpace(rest)) > 0 {
+			return nil, fmt.Errorf(`unexpected %q after value`, rest)
+		}
+		return v, nil
+	}
+	switch val {
+	case `~`, `null`, `Null`, `NULL`:
+		return nil, nil
+	}
+	return val, nil
+}
+
+// yamlFlow parses the quoted string or flow collection at the start of
+// s returning the rest.
+func yamlFlow(s string) (any, string, error) {
+	s = strings.TrimLeft(s, ` `)
+	if len(s) == 0 {
+		return nil, s, nil
+	}
+	switch s[0] {
+
+	case '"':
+		end := 1
+		for ; end < len(s) && s[end] != '"'; end++ {
+			if s[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(s) {
+			return nil, ``, fmt.Errorf(`unterminated string: %v`, s)
+		}
+		str, err := strconv.Unquote(s[:end+1])
+		if err != nil {
+			return nil, ``, fmt.Errorf(`invalid string %v: %w`, s[:end+1], err)
+		}
+		return str, s[end+1:], nil
+
+	case '\'':
+		var buf strings.Builder
+		for i := 1; i < len(s); i++ {
+			if s[i] != '\'' {
+				buf.WriteByte(s[i])
+				continue
+			}
+			if i+1 < len(s) && s[i+1] == '\'' {
+				buf.WriteByte('\'')
+				i++
+				continue
+			}
+			return buf.String(), s[i+1:], nil
+		}
+		return nil, ``, fmt.Errorf(`unterminated string: %v`, s)
+
+	case '[', '{':
+		open, end := s[0], byte(']')
+		if open == '{' {
+			end = '}'
+		}
+		list, m := []any{}, map[string]any{}
+		s = strings.TrimLeft(s[1:], ` `)
+		for len(s) > 0 && s[0] != end {
+			var key, val any
+			var err error
+			key, s, err = yamlFlowItem(s)
+			if err != nil {
+				return nil, ``, err
+			}
+			if open == '{' {
+				if !strings.HasPrefix(s, `:`) {
+					return nil, ``, fmt.Errorf(`expected : after key %v`, key)
+				}
+				if val, s, err = yamlFlowItem(s[1:]); err != nil {
+					return nil, ``, err
+				}
+				k, _ := key.(string)
+				m[k] = val
+			} else {
+				list = append(list, key)
+			}
+			s = strings.TrimLeft(s, ` `)
+			if strings.HasPrefix(s, `,`) {
+				s = strings.TrimLeft(s[1:], ` `)
+			} else if len(s) > 0 && s[0] != end {
+				return nil, ``, fmt.Errorf(`expected , or %c: %v`, end, s)
+			}
+		}
+		if len(s) == 0 {
+			return nil, ``, fmt.Errorf(`missing %c`, end)
+		}
+		if open == '{' {
+			return m, s[1:], nil
+		}
+		return list, s[1:], nil
+	}
+	return yamlFlowItem(s)
+}
+
+// yamlFlowItem parses a single item (or key) within a flow collection.
+func yamlFlowItem(s string) (any, string, error) {
+	s = strings.TrimLeft(s, ` `)
+	if len(s) > 0 && strings.IndexByte(`"'[{`, s[0]) >= 0 {
+		v, rest, err := yamlFlow(s)
+		return v, strings.TrimLeft(rest, ` `), err
+	}
+	end := strings.IndexAny(s, `,]}`)
+	if i := strings.Index(s, `: `); i >= 0 && (end < 0 || i < end) {
+		end = i
+	}
+	if end < 0 {
+		end = len(s)
+	}
+	v, _ := yamlScalar(strings.TrimSpace(s[:end]))
+	return v, s[end:], nil
+}
+
+// yamlStripComment removes any comment (# at the start or after
+// a space) that is not within a quoted string. Quotes are only
+// considered as such when they begin a value.
+func yamlStripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return text[:i]
+		case (c == '"' || c == '\'') &&
+			(i == 0 || strings.IndexByte(" \t[{,:-", text[i-1]) >= 0):
+			quote = c
+		}
+	}
+	return text
+}
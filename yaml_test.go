@@ -0,0 +1,89 @@
+// Copyright 2022 Robert S. Muhlestein.
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"errors"
+	"fmt"
+	"testing/fstest"
+
+	"github.com/rwxrob/get"
+)
+
+func ExampleString_yaml() {
+
+	g := &get.Getter{
+		ConfDir: `/conf`,
+		FS: fstest.MapFS{
+			`conf/gh/hosts.yml`: {Data: []byte(`# managed by gh
+github.com:
+    user: rwxrob
+    oauth_token: gho_sometoken # keep secret
+    git_protocol: "ssh"
+servers:
+  - name: alpha
+    ports: [22, 443]
+  - name: 'beta''s'
+notes: |
+  first
+  second
+folded: >-
+  folded
+  text
+
+  para
+    indented
+  end
+`)},
+		},
+	}
+
+	for _, target := range []string{
+		`conf.yaml:gh/hosts.yml#github.com.oauth_token`,
+		`conf.yaml:gh/hosts.yml#github.com.git_protocol`,
+		`conf.yaml:gh/hosts.yml#servers.1.name`,
+		`conf.yaml:gh/hosts.yml#servers.0.ports.1`,
+		`conf.yaml:gh/hosts.yml#notes`,
+		`conf.yaml:gh/hosts.yml#folded`,
+	} {
+		it, err := g.String(target)
+		fmt.Printf("%q %v\n", it, err)
+	}
+
+	// Output:
+	// "gho_sometoken" <nil>
+	// "ssh" <nil>
+	// "beta's" <nil>
+	// "443" <nil>
+	// "first\nsecond\n" <nil>
+	// "folded text\npara\n  indented\nend" <nil>
+}
+
+func ExampleString_yaml_errors() {
+
+	g := &get.Getter{
+		FS: fstest.MapFS{
+			`hosts.yml`: {Data: []byte("github.com:\n  user: rwxrob\n")},
+			`block.yml`: {Data: []byte("a: |\n    foo\n  b\n")},
+		},
+	}
+
+	_, err := g.String(`file.yaml:hosts.yml#github.com.oauth_token`)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, get.ErrNotFound))
+
+	_, err = g.String(`file.yaml:hosts.yml#github.com`)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, get.ErrNotScalar))
+
+	_, err = g.String(`file.yaml:block.yml#a`)
+	fmt.Println(err)
+
+	// Output:
+	// get: file.yaml: yaml github.com.oauth_token: not found
+	// true
+	// get: file.yaml: yaml github.com: not a scalar value: map
+	// true
+	// get: file.yaml: yaml a: yaml: line 3: block less indented than its first line
+}